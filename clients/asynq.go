@@ -2,12 +2,16 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"crypto/tls"
 
 	"github.com/hibiken/asynq"
+	"google.golang.org/protobuf/proto"
 )
 
 type AsynqTask interface {
@@ -17,68 +21,126 @@ type AsynqTask interface {
 
 type SchedulerClient interface {
 	RegisterTask(name string, task AsynqTask)
+	RegisterTaskWithOptions(name string, task AsynqTask, opts TaskOptions)
 	Enqueue(task *asynq.Task, at time.Time) error
+	EnqueueUnique(task *asynq.Task, at time.Time, ttl time.Duration) error
+	Schedule(cronspec string, task *asynq.Task) (entryID string, err error)
+	Unschedule(entryID string) error
+	SetErrorHandler(fn func(ctx context.Context, task *asynq.Task, err error))
 	Start() error
+	Ping() error
+	Shutdown() error
 }
 
 type AsynqClient struct {
 	asyncClient *asynq.Client
 	mux         *asynq.ServeMux
+	redisOpts   asynq.RedisClientOpt
+	srvConfig   asynq.Config
 	srv         *asynq.Server
+
+	mu          sync.RWMutex
+	taskOptions map[string][]asynq.Option
+	// scheduler is created by the first call to Schedule.
+	scheduler *asynq.Scheduler
+	started   bool
 }
 
-func NewAsynqClient(redisURL string) *AsynqClient {
-	// Create a new asynq client
-	client := asynq.NewClient(asynq.RedisClientOpt{Addr: redisURL})
-	mux := asynq.NewServeMux()
-	srv := asynq.NewServer(
-		asynq.RedisClientOpt{Addr: redisURL},
-		asynq.Config{
-			Concurrency: 3,
-			Queues: map[string]int{
-				"default": 1,
-			},
-		},
-	)
+// TaskOptions configures how tasks of a registered type are enqueued.
+type TaskOptions struct {
+	// MaxRetry is how many times a failed task is retried. Nil uses asynq's
+	// default; zero disables retries.
+	MaxRetry *int
 
-	return &AsynqClient{
-		asyncClient: client,
-		mux:         mux,
-		srv:         srv,
+	// Timeout bounds each run of the task. Zero uses asynq's default.
+	Timeout time.Duration
+
+	// Queue is the queue the task is enqueued to. Empty uses "default".
+	Queue string
+}
+
+func (o TaskOptions) asynqOptions() []asynq.Option {
+	var opts []asynq.Option
+	if o.MaxRetry != nil {
+		opts = append(opts, asynq.MaxRetry(*o.MaxRetry))
 	}
+	if o.Timeout > 0 {
+		opts = append(opts, asynq.Timeout(o.Timeout))
+	}
+	if o.Queue != "" {
+		opts = append(opts, asynq.Queue(o.Queue))
+	}
+	return opts
+}
+
+// AsynqConfig configures an AsynqClient.
+type AsynqConfig struct {
+	Username string
+	Password string
+	UseTLS   bool
+
+	// Concurrency is the number of tasks processed at once. Defaults to 3.
+	Concurrency int
+
+	// Queues maps queue names to their priority; a queue with priority 6 is
+	// processed twice as often as one with priority 3. Defaults to a single
+	// "default" queue.
+	Queues map[string]int
+}
+
+func NewAsynqClient(redisURL string) *AsynqClient {
+	return NewAsynqClientFromConfig(redisURL, AsynqConfig{})
 }
 
 func NewAsynqClientWithConfig(redisURL, username, password string, useTLS bool) *AsynqClient {
+	return NewAsynqClientFromConfig(redisURL, AsynqConfig{
+		Username: username,
+		Password: password,
+		UseTLS:   useTLS,
+	})
+}
+
+// NewAsynqClientFromConfig creates a client with the given connection,
+// concurrency and queue settings. Unset settings use the defaults of
+// NewAsynqClient.
+func NewAsynqClientFromConfig(redisURL string, cfg AsynqConfig) *AsynqClient {
 	// Create Redis client options with full configuration
 	redisOpts := asynq.RedisClientOpt{
 		Addr:     redisURL,
-		Username: username,
-		Password: password,
+		Username: cfg.Username,
+		Password: cfg.Password,
 	}
 
-	if useTLS {
+	if cfg.UseTLS {
 		redisOpts.TLSConfig = &tls.Config{
 			InsecureSkipVerify: true,
 		}
 	}
 
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = 3
+	}
+	queues := cfg.Queues
+	if len(queues) == 0 {
+		queues = map[string]int{
+			"default": 1,
+		}
+	}
+
 	// Create a new asynq client with full configuration
 	client := asynq.NewClient(redisOpts)
 	mux := asynq.NewServeMux()
-	srv := asynq.NewServer(
-		redisOpts,
-		asynq.Config{
-			Concurrency: 3,
-			Queues: map[string]int{
-				"default": 1,
-			},
-		},
-	)
 
 	return &AsynqClient{
 		asyncClient: client,
 		mux:         mux,
-		srv:         srv,
+		redisOpts:   redisOpts,
+		srvConfig: asynq.Config{
+			Concurrency: concurrency,
+			Queues:      queues,
+		},
+		taskOptions: make(map[string][]asynq.Option),
 	}
 }
 
@@ -86,28 +148,174 @@ func (c *AsynqClient) RegisterTask(name string, task AsynqTask) {
 	c.mux.HandleFunc(name, task.Handler)
 }
 
+// RegisterTaskWithOptions registers task like RegisterTask, and applies opts
+// whenever a task of type name is enqueued.
+func (c *AsynqClient) RegisterTaskWithOptions(name string, task AsynqTask, opts TaskOptions) {
+	c.mu.Lock()
+	c.taskOptions[name] = opts.asynqOptions()
+	c.mu.Unlock()
+	c.RegisterTask(name, task)
+}
+
 func (c *AsynqClient) Enqueue(task *asynq.Task, at time.Time) error {
-	_, err := c.asyncClient.Enqueue(task, asynq.ProcessAt(at))
+	opts := append([]asynq.Option{asynq.ProcessAt(at)}, c.optionsFor(task)...)
+	_, err := c.asyncClient.Enqueue(task, opts...)
 	return err
 }
 
+// ErrTaskAlreadyQueued is returned by EnqueueUnique when an identical task is
+// already pending. Callers wanting the task to run once can treat it as success.
+var ErrTaskAlreadyQueued = errors.New("asynq: task already queued")
+
+// EnqueueUnique enqueues task like Enqueue unless a task with the same type
+// and payload was enqueued within ttl and has not finished, in which case it
+// returns ErrTaskAlreadyQueued.
+func (c *AsynqClient) EnqueueUnique(task *asynq.Task, at time.Time, ttl time.Duration) error {
+	opts := append([]asynq.Option{asynq.ProcessAt(at)}, c.optionsFor(task)...)
+	opts = append(opts, asynq.Unique(ttl))
+	_, err := c.asyncClient.Enqueue(task, opts...)
+	if errors.Is(err, asynq.ErrDuplicateTask) {
+		return ErrTaskAlreadyQueued
+	}
+	return err
+}
+
+// Schedule enqueues task periodically according to cronspec, e.g.
+// "*/5 * * * *" or "@every 5m". Scheduled tasks start being enqueued once
+// Start is called. The returned ID can be passed to Unschedule.
+func (c *AsynqClient) Schedule(cronspec string, task *asynq.Task) (string, error) {
+	scheduler, err := c.getScheduler()
+	if err != nil {
+		return "", err
+	}
+	return scheduler.Register(cronspec, task, c.optionsFor(task)...)
+}
+
+// Unschedule stops enqueuing the task scheduled with entryID.
+func (c *AsynqClient) Unschedule(entryID string) error {
+	c.mu.RLock()
+	scheduler := c.scheduler
+	c.mu.RUnlock()
+	if scheduler == nil {
+		return fmt.Errorf("asynq: no scheduler entry found")
+	}
+	return scheduler.Unregister(entryID)
+}
+
+// getScheduler returns the scheduler, creating it on first use so a client
+// that schedules no tasks never opens a scheduler connection. A scheduler
+// created after Start is started right away.
+func (c *AsynqClient) getScheduler() (*asynq.Scheduler, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.scheduler == nil {
+		scheduler := asynq.NewScheduler(c.redisOpts, nil)
+		if c.started {
+			if err := scheduler.Start(); err != nil {
+				return nil, fmt.Errorf("failed to start asynq scheduler: %w", err)
+			}
+		}
+		c.scheduler = scheduler
+	}
+	return c.scheduler, nil
+}
+
+// optionsFor returns the options registered for the task's type.
+func (c *AsynqClient) optionsFor(task *asynq.Task) []asynq.Option {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.taskOptions[task.Type()]
+}
+
+// SetErrorHandler sets a function called whenever a task fails, e.g. to count
+// failures. Use asynq.GetRetryCount and asynq.GetMaxRetry on ctx to tell
+// whether the task will be retried. It must be called before Start.
+func (c *AsynqClient) SetErrorHandler(fn func(ctx context.Context, task *asynq.Task, err error)) {
+	c.srvConfig.ErrorHandler = asynq.ErrorHandlerFunc(fn)
+}
+
+// Start starts processing tasks and enqueuing scheduled ones, and returns once
+// the server is running. It does not handle OS signals; call Shutdown when the
+// process is asked to stop.
 func (c *AsynqClient) Start() error {
-	// Create error channel to catch any server errors
-	errChan := make(chan error, 1)
-
-	// Start the server in a separate goroutine
-	go func() {
-		if err := c.srv.Run(c.mux); err != nil {
-			fmt.Println("Error starting asynq server")
-			errChan <- err
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	srv := asynq.NewServer(c.redisOpts, c.srvConfig)
+	if err := srv.Start(c.mux); err != nil {
+		return fmt.Errorf("failed to start asynq server: %w", err)
+	}
+	if c.scheduler != nil {
+		if err := c.scheduler.Start(); err != nil {
+			srv.Shutdown()
+			return fmt.Errorf("failed to start asynq scheduler: %w", err)
 		}
-	}()
+	}
+
+	// Only a running client starts schedulers created by later calls to
+	// Schedule.
+	c.srv = srv
+	c.started = true
+	return nil
+}
+
+// Ping verifies the connection to Redis.
+func (c *AsynqClient) Ping() error {
+	return c.asyncClient.Ping()
+}
+
+// Shutdown stops the server, waiting for active tasks to finish, stops the
+// scheduler and closes the client. The client cannot be started again.
+func (c *AsynqClient) Shutdown() error {
+	c.mu.RLock()
+	srv, scheduler := c.srv, c.scheduler
+	c.mu.RUnlock()
+
+	if srv != nil {
+		srv.Shutdown()
+	}
+	// A scheduler that was never started has not connected to Redis, as
+	// registering tasks does not use it, so it has nothing to close.
+	if scheduler != nil {
+		scheduler.Shutdown()
+	}
+	return c.asyncClient.Close()
+}
+
+// NewJSONTask creates a task of type name with payload encoded as JSON. Decode
+// it in the handler with ParseJSONPayload.
+func NewJSONTask(name string, payload interface{}, opts ...asynq.Option) (*asynq.Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return asynq.NewTask(name, data, opts...), nil
+}
+
+// ParseJSONPayload decodes the JSON payload of a task created with
+// NewJSONTask into value.
+func ParseJSONPayload(task *asynq.Task, value interface{}) error {
+	if err := json.Unmarshal(task.Payload(), value); err != nil {
+		return fmt.Errorf("failed to unmarshal payload of %s task: %w", task.Type(), err)
+	}
+	return nil
+}
+
+// NewProtoTask creates a task of type name with msg encoded as its payload.
+// Decode it in the handler with ParseProtoPayload.
+func NewProtoTask(name string, msg proto.Message, opts ...asynq.Option) (*asynq.Task, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal proto: %w", err)
+	}
+	return asynq.NewTask(name, data, opts...), nil
+}
 
-	// Return any immediate error, otherwise return nil
-	select {
-	case err := <-errChan:
-		return err
-	case <-time.After(time.Second): // Give server a moment to start
-		return nil
+// ParseProtoPayload decodes the payload of a task created with NewProtoTask
+// into msg.
+func ParseProtoPayload(task *asynq.Task, msg proto.Message) error {
+	if err := proto.Unmarshal(task.Payload(), msg); err != nil {
+		return fmt.Errorf("failed to unmarshal proto payload of %s task: %w", task.Type(), err)
 	}
+	return nil
 }
@@ -0,0 +1,121 @@
+package clients
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type emailPayload struct {
+	To      string   `json:"to"`
+	Subject string   `json:"subject"`
+	Tags    []string `json:"tags"`
+}
+
+func TestJSONTaskRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload interface{}
+		decoded func() interface{}
+	}{
+		{
+			name:    "struct",
+			payload: &emailPayload{To: "a@example.com", Subject: "hi", Tags: []string{"welcome"}},
+			decoded: func() interface{} { return &emailPayload{} },
+		},
+		{
+			name:    "map",
+			payload: &map[string]int{"a": 1, "b": 2},
+			decoded: func() interface{} { return &map[string]int{} },
+		},
+		{
+			name:    "string",
+			payload: ptr("hello"),
+			decoded: func() interface{} { return new(string) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := NewJSONTask("email:send", tt.payload)
+			if err != nil {
+				t.Fatalf("NewJSONTask: %v", err)
+			}
+			if task.Type() != "email:send" {
+				t.Errorf("Type = %q, want %q", task.Type(), "email:send")
+			}
+
+			got := tt.decoded()
+			if err := ParseJSONPayload(task, got); err != nil {
+				t.Fatalf("ParseJSONPayload: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.payload) {
+				t.Errorf("decoded %#v, want %#v", got, tt.payload)
+			}
+		})
+	}
+}
+
+func TestProtoTaskRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     proto.Message
+		decoded proto.Message
+	}{
+		{name: "string", msg: wrapperspb.String("hello"), decoded: &wrapperspb.StringValue{}},
+		{name: "int64", msg: wrapperspb.Int64(42), decoded: &wrapperspb.Int64Value{}},
+		{name: "empty", msg: &wrapperspb.BoolValue{}, decoded: &wrapperspb.BoolValue{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := NewProtoTask("report:build", tt.msg)
+			if err != nil {
+				t.Fatalf("NewProtoTask: %v", err)
+			}
+			if err := ParseProtoPayload(task, tt.decoded); err != nil {
+				t.Fatalf("ParseProtoPayload: %v", err)
+			}
+			if !proto.Equal(tt.decoded, tt.msg) {
+				t.Errorf("decoded %v, want %v", tt.decoded, tt.msg)
+			}
+		})
+	}
+}
+
+func TestParsePayloadMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(*asynq.Task) error
+	}{
+		{
+			name:  "json",
+			parse: func(task *asynq.Task) error { return ParseJSONPayload(task, &emailPayload{}) },
+		},
+		{
+			name:  "proto",
+			parse: func(task *asynq.Task) error { return ParseProtoPayload(task, &wrapperspb.StringValue{}) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := asynq.NewTask("email:send", []byte{0xff, '{'})
+			err := tt.parse(task)
+			if err == nil {
+				t.Fatal("expected an error for a malformed payload")
+			}
+			if !strings.Contains(err.Error(), "email:send") {
+				t.Errorf("error %q does not name the task type", err)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
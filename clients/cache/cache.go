@@ -2,15 +2,29 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
+// ErrNotFound is returned, wrapped, when a key does not exist or has expired.
+// It is the same error as clients.ErrNotFound, so a miss can be detected with
+// errors.Is whichever backend is used.
+var ErrNotFound = errors.New("not found")
+
 type Cache interface {
 	Get(context.Context, string, interface{}) error
 	GetMany(context.Context, []string, interface{}) error
 	Set(context.Context, string, interface{}, time.Duration) error
 	SetMany(context.Context, []string, []interface{}, time.Duration) error
 	Delete(context.Context, string) error
+	// DeleteMany deletes every given key. Missing keys are ignored.
+	DeleteMany(context.Context, ...string) error
+	// DeleteByPattern deletes every key matching a glob pattern, as listed
+	// by ScanKeys.
+	DeleteByPattern(context.Context, string) error
 
 	ScanKeys(context.Context, string) ([]string, error)
 
@@ -18,4 +32,37 @@ type Cache interface {
 	IncrBy(context.Context, string, int64) (int64, error)
 	Decr(context.Context, string) error
 	DecrBy(context.Context, string, int64) (int64, error)
+
+	// GetOrSet decodes the value at key into dest. On a miss it calls
+	// compute, caches the result for ttl and decodes it into dest.
+	GetOrSet(context.Context, string, interface{}, time.Duration, func(context.Context) (interface{}, error)) error
+}
+
+var (
+	_ Cache = (*FSCache)(nil)
+	_ Cache = (*MemoryCache)(nil)
+)
+
+// load computes the value for a missed key, stores it with set and decodes it
+// into dest. Concurrent loads of the same key in loads share a single call to
+// compute.
+func load(ctx context.Context, loads *singleflight.Group, key string, dest interface{}, ttl time.Duration, compute func(context.Context) (interface{}, error), set func(context.Context, string, interface{}, time.Duration) error) error {
+	loaded, err, _ := loads.Do(key, func() (interface{}, error) {
+		value, err := compute(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if err := set(ctx, key, json.RawMessage(b), ttl); err != nil {
+			return nil, err
+		}
+		return b, nil
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(loaded.([]byte), dest)
 }
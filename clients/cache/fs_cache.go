@@ -3,17 +3,33 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 const filename = "entry" // Will be a json
 
+// metaFilename holds an entry's metadata. It is only written for entries
+// that expire.
+const metaFilename = "meta.json"
+
 type entry string
 
+type entryMeta struct {
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type FSCache struct {
 	cacheDir string
+	locks    sync.Map // key -> *sync.Mutex
+	loads    singleflight.Group
 }
 
 func NewEntry(data interface{}) (entry, error) {
@@ -38,63 +54,183 @@ func NewFSCache(cacheDir string) *FSCache {
 	}
 }
 
-func (c *FSCache) Set(_ context.Context, key string, data interface{}, _ time.Duration) error {
-	entry, err := NewEntry(data)
+// lock serializes writes to key within this process.
+func (c *FSCache) lock(key string) func() {
+	mu, _ := c.locks.LoadOrStore(key, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// Set stores data under key. A zero expiration means the entry never expires.
+func (c *FSCache) Set(_ context.Context, key string, data interface{}, expiration time.Duration) error {
+	defer c.lock(key)()
+
+	dirPath, err := c.writeEntry(key, data)
 	if err != nil {
 		return err
 	}
+	return c.writeMeta(dirPath, expiration)
+}
+
+// writeEntry stores data as the entry for key, leaving its expiry unchanged,
+// and returns the entry's directory.
+func (c *FSCache) writeEntry(key string, data interface{}) (string, error) {
+	entry, err := NewEntry(data)
+	if err != nil {
+		return "", err
+	}
 
 	// Create directory if it doesn't exist
 	dirPath := filepath.Join(c.cacheDir, key)
 	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		return "", err
+	}
+
+	if err := writeFileAtomic(filepath.Join(dirPath, filename), []byte(entry)); err != nil {
+		return "", err
+	}
+	return dirPath, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// into place, so readers never see a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
 		return err
 	}
+	defer os.Remove(file.Name())
 
-	location := filepath.Join(dirPath, filename)
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	// CreateTemp creates the file with mode 0600; use the usual 0644.
+	if err := file.Chmod(0644); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
 
-	// Write the file
-	file, err := os.Create(location)
+// writeMeta records when the entry in dirPath expires, or removes any previous
+// expiry if it no longer has one.
+func (c *FSCache) writeMeta(dirPath string, expiration time.Duration) error {
+	location := filepath.Join(dirPath, metaFilename)
+	if expiration <= 0 {
+		if err := os.Remove(location); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	b, err := json.Marshal(entryMeta{ExpiresAt: time.Now().Add(expiration)})
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	return writeFileAtomic(location, b)
+}
+
+// expired reports whether the entry in dirPath has passed its expiry.
+func (c *FSCache) expired(dirPath string) (bool, error) {
+	b, err := os.ReadFile(filepath.Join(dirPath, metaFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
 
-	_, err = file.WriteString(string(entry))
+	var meta entryMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return false, err
+	}
+	return time.Now().After(meta.ExpiresAt), nil
+}
+
+// Get the data from the cache and unmarshal it into the data object. An
+// expired entry is deleted and reported as missing. A missing entry's error
+// wraps both ErrNotFound and os.ErrNotExist.
+func (c *FSCache) Get(ctx context.Context, key string, data interface{}) error {
+	expired, err := c.expired(filepath.Join(c.cacheDir, key))
 	if err != nil {
 		return err
 	}
-	return nil
+	if expired {
+		// Re-check under the key's lock, so an entry replaced by a concurrent
+		// Set is not deleted.
+		unlock := c.lock(key)
+		err := c.removeExpired(key)
+		unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return c.read(key, data)
 }
 
-// Get the data from the cache and unmarshal it into the data object
-func (c *FSCache) Get(_ context.Context, key string, data interface{}) error {
-	location := filepath.Join(c.cacheDir, key, filename)
+// removeExpired deletes the entry for key if it has expired. The caller must
+// hold the key's lock.
+func (c *FSCache) removeExpired(key string) error {
+	dirPath := filepath.Join(c.cacheDir, key)
+	expired, err := c.expired(dirPath)
+	if err != nil || !expired {
+		return err
+	}
+	return os.RemoveAll(dirPath)
+}
 
-	// Read the file
-	file, err := os.Open(location)
+// read decodes the entry for key into data, regardless of its expiry.
+func (c *FSCache) read(key string, data interface{}) error {
+	file, err := os.Open(filepath.Join(c.cacheDir, key, filename))
 	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("key %s %w: %w", key, ErrNotFound, err)
+		}
 		return err
 	}
 	defer file.Close()
 
-	// Unmarshal the data
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(data)
-	if err != nil {
+	return json.NewDecoder(file).Decode(data)
+}
+
+// GetOrSet decodes the entry for key into dest. On a miss it calls compute,
+// caches the result for ttl and decodes it into dest. Concurrent misses for
+// the same key within this process share a single call to compute.
+func (c *FSCache) GetOrSet(ctx context.Context, key string, dest interface{}, ttl time.Duration, compute func(context.Context) (interface{}, error)) error {
+	err := c.Get(ctx, key, dest)
+	if err == nil || !errors.Is(err, ErrNotFound) {
 		return err
 	}
-	return nil
+	return load(ctx, &c.loads, key, dest, ttl, compute, c.Set)
 }
 
+// GetMany decodes the entries for keys into data, which must be a pointer to
+// a slice. Missing or expired keys are skipped.
 func (c *FSCache) GetMany(ctx context.Context, keys []string, data interface{}) error {
-	items := make([]interface{}, 0)
+	slice := reflect.ValueOf(data)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("data must be a pointer to a slice, got %T", data)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+
+	items := reflect.MakeSlice(slice.Type(), 0, len(keys))
 	for _, key := range keys {
-		item := c.Get(ctx, key, data)
-		if item != nil {
-			items = append(items, item)
+		item := reflect.New(elemType)
+		if err := c.Get(ctx, key, item.Interface()); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return err
 		}
+		items = reflect.Append(items, item.Elem())
 	}
 
+	slice.Set(items)
 	return nil
 }
 
@@ -103,6 +239,31 @@ func (c *FSCache) Delete(_ context.Context, key string) error {
 	return os.RemoveAll(location)
 }
 
+func (c *FSCache) DeleteMany(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := c.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteByPattern deletes the entries whose keys match a glob pattern,
+// relative to the cache directory.
+func (c *FSCache) DeleteByPattern(ctx context.Context, pattern string) error {
+	paths, err := c.ScanKeys(ctx, pattern)
+	if err != nil {
+		return err
+	}
+	// ScanKeys returns the entries' directories rather than their keys.
+	for _, path := range paths {
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *FSCache) ScanKeys(ctx context.Context, pattern string) ([]string, error) {
 	files, err := filepath.Glob(filepath.Join(c.cacheDir, pattern))
 	if err != nil {
@@ -112,19 +273,38 @@ func (c *FSCache) ScanKeys(ctx context.Context, pattern string) ([]string, error
 }
 
 func (c *FSCache) Incr(ctx context.Context, key string) error {
-	return nil
+	_, err := c.IncrBy(ctx, key, 1)
+	return err
 }
 
+// IncrBy adds amount to the integer stored at key and returns the new value.
+// A missing or expired key counts from zero, and an existing expiry is kept.
 func (c *FSCache) IncrBy(ctx context.Context, key string, amount int64) (int64, error) {
-	return 0, nil
+	defer c.lock(key)()
+
+	if err := c.removeExpired(key); err != nil {
+		return 0, err
+	}
+	var value int64
+	if err := c.read(key, &value); err != nil && !errors.Is(err, ErrNotFound) {
+		return 0, err
+	}
+
+	value += amount
+	if _, err := c.writeEntry(key, value); err != nil {
+		return 0, err
+	}
+	return value, nil
 }
 
 func (c *FSCache) Decr(ctx context.Context, key string) error {
-	return nil
+	_, err := c.IncrBy(ctx, key, -1)
+	return err
 }
 
+// DecrBy subtracts amount from the integer stored at key and returns the new value.
 func (c *FSCache) DecrBy(ctx context.Context, key string, amount int64) (int64, error) {
-	return 0, nil
+	return c.IncrBy(ctx, key, -amount)
 }
 
 func (c *FSCache) SetMany(ctx context.Context, keys []string, values []interface{}, expiration time.Duration) error {
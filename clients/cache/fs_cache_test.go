@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFSCacheGetMany(t *testing.T) {
+	ctx := context.Background()
+	c := NewFSCache(t.TempDir())
+
+	const n = 10
+	keys := make([]string, n)
+	want := make([]int, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+		want[i] = i * i
+		if err := c.Set(ctx, keys[i], want[i], 0); err != nil {
+			t.Fatalf("Set(%q): %v", keys[i], err)
+		}
+	}
+
+	var got []int
+	if err := c.GetMany(ctx, keys, &got); err != nil {
+		t.Fatalf("GetMany: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMany = %v, want %v", got, want)
+	}
+}
+
+func TestFSCacheGetManySkipsMissingAndExpired(t *testing.T) {
+	ctx := context.Background()
+	c := NewFSCache(t.TempDir())
+
+	if err := c.Set(ctx, "a", "first", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set(ctx, "expired", "stale", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set(ctx, "b", "second", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	var got []string
+	if err := c.GetMany(ctx, []string{"a", "missing", "expired", "b"}, &got); err != nil {
+		t.Fatalf("GetMany: %v", err)
+	}
+	if want := []string{"first", "second"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMany = %v, want %v", got, want)
+	}
+}
+
+func TestFSCacheIncrConcurrent(t *testing.T) {
+	ctx := context.Background()
+	c := NewFSCache(t.TempDir())
+
+	const goroutines, increments = 20, 25
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; j++ {
+				if err := c.Incr(ctx, "counter"); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Incr: %v", err)
+	}
+
+	var got int64
+	if err := c.Get(ctx, "counter", &got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if want := int64(goroutines * increments); got != want {
+		t.Errorf("counter = %d, want %d", got, want)
+	}
+}
+
+func TestFSCacheIncrByKeepsExpiry(t *testing.T) {
+	ctx := context.Background()
+	c := NewFSCache(t.TempDir())
+
+	if err := c.Set(ctx, "counter", 5, 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.IncrBy(ctx, "counter", 3); err != nil || got != 8 {
+		t.Fatalf("IncrBy = %d, %v, want 8, nil", got, err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// The expiry set by Set still applies, so the counter starts over.
+	if got, err := c.IncrBy(ctx, "counter", 1); err != nil || got != 1 {
+		t.Fatalf("IncrBy after expiry = %d, %v, want 1, nil", got, err)
+	}
+}
@@ -0,0 +1,263 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// MemoryCache is an in-process Cache, useful in tests and small services.
+// Values are stored JSON-encoded, like in the other caches, so callers never
+// share memory with the cache.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List // front is most recently used
+	loads      singleflight.Group
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // zero means no expiry
+}
+
+// NewMemoryCache creates a memory cache holding at most maxEntries entries,
+// evicting the least recently used entry when full. A maxEntries of zero
+// means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// lookup returns the live entry for key, removing it if it has expired.
+// The caller must hold c.mu.
+func (c *MemoryCache) lookup(key string) (*memoryEntry, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*memoryEntry)
+	if !e.expiresAt.IsZero() && time.Now().After(e.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return e, true
+}
+
+// store sets the value and expiry of key, evicting the least recently used
+// entry if the cache is full. The caller must hold c.mu.
+func (c *MemoryCache) store(key string, value []byte, expiresAt time.Time) {
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*memoryEntry)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	if c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *MemoryCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*memoryEntry).key)
+}
+
+// get returns the encoded value of key. The value is read while holding c.mu,
+// as store replaces it when the key is set again.
+func (c *MemoryCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.lookup(key)
+	if !ok {
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *MemoryCache) Get(_ context.Context, key string, data interface{}) error {
+	value, ok := c.get(key)
+	if !ok {
+		return fmt.Errorf("key %s %w", key, ErrNotFound)
+	}
+	return json.Unmarshal(value, data)
+}
+
+// GetOrSet decodes the entry for key into dest. On a miss it calls compute,
+// caches the result for ttl and decodes it into dest. Concurrent misses for
+// the same key share a single call to compute.
+func (c *MemoryCache) GetOrSet(ctx context.Context, key string, dest interface{}, ttl time.Duration, compute func(context.Context) (interface{}, error)) error {
+	if value, ok := c.get(key); ok {
+		return json.Unmarshal(value, dest)
+	}
+	return load(ctx, &c.loads, key, dest, ttl, compute, c.Set)
+}
+
+// GetMany decodes the entries for keys into data, which must be a pointer to
+// a slice. Missing or expired keys are skipped.
+func (c *MemoryCache) GetMany(_ context.Context, keys []string, data interface{}) error {
+	slice := reflect.ValueOf(data)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("data must be a pointer to a slice, got %T", data)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+
+	c.mu.Lock()
+	values := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		if e, ok := c.lookup(key); ok {
+			values = append(values, e.value)
+		}
+	}
+	c.mu.Unlock()
+
+	items := reflect.MakeSlice(slice.Type(), 0, len(values))
+	for _, value := range values {
+		item := reflect.New(elemType)
+		if err := json.Unmarshal(value, item.Interface()); err != nil {
+			return err
+		}
+		items = reflect.Append(items, item.Elem())
+	}
+
+	slice.Set(items)
+	return nil
+}
+
+// Set stores data under key. A zero expiration means the entry never expires.
+func (c *MemoryCache) Set(_ context.Context, key string, data interface{}, expiration time.Duration) error {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt time.Time
+	if expiration > 0 {
+		expiresAt = time.Now().Add(expiration)
+	}
+
+	c.mu.Lock()
+	c.store(key, value, expiresAt)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *MemoryCache) SetMany(ctx context.Context, keys []string, values []interface{}, expiration time.Duration) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("keys and values must be the same length")
+	}
+	for i, key := range keys {
+		if err := c.Set(ctx, key, values[i], expiration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *MemoryCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *MemoryCache) DeleteMany(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	for _, key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.remove(elem)
+		}
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+// DeleteByPattern deletes the keys matching a glob pattern, e.g. "user:*".
+func (c *MemoryCache) DeleteByPattern(ctx context.Context, pattern string) error {
+	keys, err := c.ScanKeys(ctx, pattern)
+	if err != nil {
+		return err
+	}
+	return c.DeleteMany(ctx, keys...)
+}
+
+// ScanKeys returns the keys matching a glob pattern, e.g. "user:*".
+func (c *MemoryCache) ScanKeys(_ context.Context, pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var keys []string
+	for key, elem := range c.entries {
+		e := elem.Value.(*memoryEntry)
+		if !e.expiresAt.IsZero() && now.After(e.expiresAt) {
+			continue
+		}
+		if ok, _ := path.Match(pattern, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (c *MemoryCache) Incr(ctx context.Context, key string) error {
+	_, err := c.IncrBy(ctx, key, 1)
+	return err
+}
+
+// IncrBy adds amount to the integer stored at key and returns the new value.
+// A missing or expired key counts from zero, and an existing expiry is kept.
+func (c *MemoryCache) IncrBy(_ context.Context, key string, amount int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var value int64
+	var expiresAt time.Time
+	if e, ok := c.lookup(key); ok {
+		if err := json.Unmarshal(e.value, &value); err != nil {
+			return 0, fmt.Errorf("value at %s is not an integer: %w", key, err)
+		}
+		expiresAt = e.expiresAt
+	}
+
+	value += amount
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return 0, err
+	}
+	c.store(key, encoded, expiresAt)
+	return value, nil
+}
+
+func (c *MemoryCache) Decr(ctx context.Context, key string) error {
+	_, err := c.IncrBy(ctx, key, -1)
+	return err
+}
+
+// DecrBy subtracts amount from the integer stored at key and returns the new value.
+func (c *MemoryCache) DecrBy(ctx context.Context, key string, amount int64) (int64, error) {
+	return c.IncrBy(ctx, key, -amount)
+}
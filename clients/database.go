@@ -1,6 +1,11 @@
 package clients
 
-import "context"
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
 
 type InsertOneRequest struct {
 	Database   string
@@ -29,12 +34,34 @@ type FindRequest struct {
 	Sort       interface{}
 }
 
+type FindPaginatedRequest struct {
+	Database   string
+	Collection string
+	Filter     interface{}
+	SortField  string
+	After      string
+	Limit      int64
+}
+
 type ExistsRequest struct {
 	Database   string
 	Collection string
 	Filter     interface{}
 }
 
+type CountRequest struct {
+	Database   string
+	Collection string
+	Filter     interface{}
+}
+
+type DistinctRequest struct {
+	Database   string
+	Collection string
+	Field      string
+	Filter     interface{}
+}
+
 type AggregateRequest struct {
 	Database   string
 	Collection string
@@ -46,6 +73,30 @@ type UpdateOneRequest struct {
 	Collection string
 	Filter     interface{}
 	Update     interface{}
+	Upsert     bool
+}
+
+type BulkWriteRequest struct {
+	Database   string
+	Collection string
+	Models     []mongo.WriteModel
+}
+
+type FindOneAndUpdateRequest struct {
+	Database    string
+	Collection  string
+	Filter      interface{}
+	Update      interface{}
+	ReturnAfter bool
+	Upsert      bool
+}
+
+type UpdateWithVersionRequest struct {
+	Database   string
+	Collection string
+	Filter     interface{}
+	Update     bson.M
+	Version    int64
 }
 
 type ReplaceOneRequest struct {
@@ -53,6 +104,7 @@ type ReplaceOneRequest struct {
 	Collection  string
 	Filter      interface{}
 	Replacement interface{}
+	Upsert      bool
 }
 
 type DatabaseClient interface {
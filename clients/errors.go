@@ -0,0 +1,35 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/micahke/mirage/clients/cache"
+)
+
+// Errors returned, wrapped, by the Redis, Mongo and Postgres clients, so
+// callers can handle them with errors.Is whichever backend they use. The
+// backend's own error stays in the chain, so e.g. errors.Is(err,
+// pgx.ErrNoRows) keeps working.
+var (
+	// ErrNotFound is returned when a key, document or row does not exist.
+	// The cache backends return it too.
+	ErrNotFound = cache.ErrNotFound
+	// ErrConflict is returned when a write conflicts with existing data,
+	// e.g. a duplicate unique key or a stale version.
+	ErrConflict = errors.New("conflict")
+	// ErrTimeout is returned when an operation ran out of time.
+	ErrTimeout = errors.New("timeout")
+)
+
+// wrapTimeout wraps err with ErrTimeout if it was caused by a context
+// deadline or a network timeout.
+func wrapTimeout(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
@@ -3,7 +3,9 @@ package clients
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 	"log"
+	"time"
 
 	firebase "firebase.google.com/go"
 	"firebase.google.com/go/auth"
@@ -18,8 +20,16 @@ type FirebaseClient interface {
 	GetUserByEmail(ctx context.Context, email string) (*auth.UserRecord, error)
 	SetDisplayName(ctx context.Context, uid string, displayName string) error
 	VerifyIdToken(ctx context.Context, idToken string) (*auth.Token, error)
-	GetEmailVerificationLink(ctx context.Context, email string) (string, error)
-	GetPasswordResetLink(ctx context.Context, email string) (string, error)
+	VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (*auth.Token, error)
+	GetEmailVerificationLink(ctx context.Context, email string, settings ...*auth.ActionCodeSettings) (string, error)
+	GetPasswordResetLink(ctx context.Context, email string, settings ...*auth.ActionCodeSettings) (string, error)
+	SetCustomClaims(ctx context.Context, uid string, claims map[string]interface{}) error
+	GetCustomClaims(ctx context.Context, uid string) (map[string]interface{}, error)
+	GetUsers(ctx context.Context, uids []string) ([]*auth.UserRecord, []string, error)
+	GetUsersByEmail(ctx context.Context, emails []string) ([]*auth.UserRecord, []string, error)
+	CreateSessionCookie(ctx context.Context, idToken string, expiresIn time.Duration) (string, error)
+	VerifySessionCookie(ctx context.Context, cookie string) (*auth.Token, error)
+	VerifySessionCookieAndCheckRevoked(ctx context.Context, cookie string) (*auth.Token, error)
 }
 
 type Client struct {
@@ -27,39 +37,40 @@ type Client struct {
 	auth *auth.Client
 }
 
-func NewFirebaseClientFromENV(ctx context.Context) *Client {
-	app, err := firebase.NewApp(ctx, nil)
+// NewFirebaseClient initializes a Firebase app with opts, e.g. credentials,
+// and its auth client. Unlike the other constructors it returns an error
+// instead of exiting the program.
+func NewFirebaseClient(ctx context.Context, opts ...option.ClientOption) (*Client, error) {
+	app, err := firebase.NewApp(ctx, nil, opts...)
 	if err != nil {
-		log.Fatalf("error initializing app: %v", err)
+		return nil, fmt.Errorf("error initializing app: %w", err)
 	}
 
 	auth, err := app.Auth(ctx)
 	if err != nil {
-		log.Fatalf("error getting auth client: %v", err)
+		return nil, fmt.Errorf("error getting auth client: %w", err)
 	}
 
 	return &Client{
 		app:  app,
 		auth: auth,
-	}
+	}, nil
 }
 
-func NewFirebaseClientFromServiceAccount(ctx context.Context, path string) *Client {
-	opt := option.WithCredentialsFile(path)
-	app, err := firebase.NewApp(ctx, nil, opt)
+func NewFirebaseClientFromENV(ctx context.Context) *Client {
+	client, err := NewFirebaseClient(ctx)
 	if err != nil {
-		log.Fatalf("error initializing app: %v", err)
+		log.Fatal(err)
 	}
+	return client
+}
 
-	auth, err := app.Auth(ctx)
+func NewFirebaseClientFromServiceAccount(ctx context.Context, path string) *Client {
+	client, err := NewFirebaseClient(ctx, option.WithCredentialsFile(path))
 	if err != nil {
-		log.Fatalf("error getting auth client: %v", err)
-	}
-
-	return &Client{
-		app:  app,
-		auth: auth,
+		log.Fatal(err)
 	}
+	return client
 }
 
 func NewFirebaseClientFromBase64String(ctx context.Context, base64Str string) *Client {
@@ -68,21 +79,11 @@ func NewFirebaseClientFromBase64String(ctx context.Context, base64Str string) *C
 		log.Fatalf("failed to decode base64 Firebase credentials: %v", err)
 	}
 
-	opt := option.WithCredentialsJSON(decoded)
-	app, err := firebase.NewApp(ctx, nil, opt)
+	client, err := NewFirebaseClient(ctx, option.WithCredentialsJSON(decoded))
 	if err != nil {
-		log.Fatalf("error initializing app: %v", err)
-	}
-
-	auth, err := app.Auth(ctx)
-	if err != nil {
-		log.Fatalf("error getting auth client: %v", err)
-	}
-
-	return &Client{
-		app:  app,
-		auth: auth,
+		log.Fatal(err)
 	}
+	return client
 }
 
 func (c *Client) CreateUser(ctx context.Context, email string, password string) (*auth.UserRecord, error) {
@@ -115,6 +116,13 @@ func (c *Client) VerifyIdToken(ctx context.Context, idToken string) (*auth.Token
 	return user, nil
 }
 
+// VerifyIDTokenAndCheckRevoked is like VerifyIdToken but also fails if the
+// token was revoked, e.g. because the user logged out everywhere, or the user
+// is disabled. It costs a request to Firebase.
+func (c *Client) VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (*auth.Token, error) {
+	return c.auth.VerifyIDTokenAndCheckRevoked(ctx, idToken)
+}
+
 func (c *Client) SetDisplayName(ctx context.Context, uid string, displayName string) error {
 	userToUpdate := &auth.UserToUpdate{}
 	userToUpdate.DisplayName(displayName)
@@ -122,18 +130,114 @@ func (c *Client) SetDisplayName(ctx context.Context, uid string, displayName str
 	return err
 }
 
-func (c *Client) GetEmailVerificationLink(ctx context.Context, email string) (string, error) {
-	link, err := c.auth.EmailVerificationLink(ctx, email)
+// GetEmailVerificationLink generates a link that verifies the user's email
+// when opened. Optional settings control where the user is sent afterwards,
+// e.g. back to the app; only the first is used.
+func (c *Client) GetEmailVerificationLink(ctx context.Context, email string, settings ...*auth.ActionCodeSettings) (string, error) {
+	var link string
+	var err error
+	if len(settings) > 0 && settings[0] != nil {
+		link, err = c.auth.EmailVerificationLinkWithSettings(ctx, email, settings[0])
+	} else {
+		link, err = c.auth.EmailVerificationLink(ctx, email)
+	}
 	if err != nil {
 		return "", err
 	}
 	return link, nil
 }
 
-func (c *Client) GetPasswordResetLink(ctx context.Context, email string) (string, error) {
-	link, err := c.auth.PasswordResetLink(ctx, email)
+// GetPasswordResetLink generates a link that lets the user choose a new
+// password. Optional settings work as in GetEmailVerificationLink.
+func (c *Client) GetPasswordResetLink(ctx context.Context, email string, settings ...*auth.ActionCodeSettings) (string, error) {
+	var link string
+	var err error
+	if len(settings) > 0 && settings[0] != nil {
+		link, err = c.auth.PasswordResetLinkWithSettings(ctx, email, settings[0])
+	} else {
+		link, err = c.auth.PasswordResetLink(ctx, email)
+	}
 	if err != nil {
 		return "", err
 	}
 	return link, nil
 }
+
+// SetCustomClaims replaces the user's custom claims, which appear in the ID
+// tokens issued to them from then on. A nil map removes all custom claims.
+func (c *Client) SetCustomClaims(ctx context.Context, uid string, claims map[string]interface{}) error {
+	return c.auth.SetCustomUserClaims(ctx, uid, claims)
+}
+
+// GetCustomClaims returns the user's custom claims, or nil if they have none.
+func (c *Client) GetCustomClaims(ctx context.Context, uid string) (map[string]interface{}, error) {
+	user, err := c.auth.GetUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return user.CustomClaims, nil
+}
+
+// getUsersBatchSize is the most identifiers Firebase accepts in one lookup.
+const getUsersBatchSize = 100
+
+// GetUsers looks up users by UID, in batches of up to 100 per request. It
+// returns the users found, in no particular order, and the UIDs not found.
+func (c *Client) GetUsers(ctx context.Context, uids []string) ([]*auth.UserRecord, []string, error) {
+	identifiers := make([]auth.UserIdentifier, len(uids))
+	for i, uid := range uids {
+		identifiers[i] = auth.UIDIdentifier{UID: uid}
+	}
+	return c.getUsers(ctx, identifiers)
+}
+
+// GetUsersByEmail looks up users by email like GetUsers, returning the emails
+// not found.
+func (c *Client) GetUsersByEmail(ctx context.Context, emails []string) ([]*auth.UserRecord, []string, error) {
+	identifiers := make([]auth.UserIdentifier, len(emails))
+	for i, email := range emails {
+		identifiers[i] = auth.EmailIdentifier{Email: email}
+	}
+	return c.getUsers(ctx, identifiers)
+}
+
+func (c *Client) getUsers(ctx context.Context, identifiers []auth.UserIdentifier) ([]*auth.UserRecord, []string, error) {
+	var users []*auth.UserRecord
+	var notFound []string
+	for start := 0; start < len(identifiers); start += getUsersBatchSize {
+		end := min(start+getUsersBatchSize, len(identifiers))
+		result, err := c.auth.GetUsers(ctx, identifiers[start:end])
+		if err != nil {
+			return users, notFound, err
+		}
+		users = append(users, result.Users...)
+		for _, id := range result.NotFound {
+			switch id := id.(type) {
+			case auth.UIDIdentifier:
+				notFound = append(notFound, id.UID)
+			case auth.EmailIdentifier:
+				notFound = append(notFound, id.Email)
+			}
+		}
+	}
+	return users, notFound, nil
+}
+
+// CreateSessionCookie exchanges an ID token for a session cookie valid for
+// expiresIn, which Firebase requires to be between 5 minutes and 2 weeks.
+func (c *Client) CreateSessionCookie(ctx context.Context, idToken string, expiresIn time.Duration) (string, error) {
+	return c.auth.SessionCookie(ctx, idToken, expiresIn)
+}
+
+// VerifySessionCookie verifies a session cookie and returns its claims. It
+// does not check whether the session was revoked.
+func (c *Client) VerifySessionCookie(ctx context.Context, cookie string) (*auth.Token, error) {
+	return c.auth.VerifySessionCookie(ctx, cookie)
+}
+
+// VerifySessionCookieAndCheckRevoked is like VerifySessionCookie but also
+// fails if the user's sessions were revoked or the user is disabled, at the
+// cost of a request to Firebase.
+func (c *Client) VerifySessionCookieAndCheckRevoked(ctx context.Context, cookie string) (*auth.Token, error) {
+	return c.auth.VerifySessionCookieAndCheckRevoked(ctx, cookie)
+}
@@ -1,7 +1,11 @@
 package clients
 
 import (
+	"context"
+	"fmt"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 type Logger interface {
@@ -11,21 +15,57 @@ type Logger interface {
 	Error(msg string, keysAndValues ...interface{})
 	Debug(msg string, keysAndValues ...interface{})
 	Fatal(msg string, keysAndValues ...interface{})
+	WithContext(ctx context.Context) Logger
 }
 
+// LogContextKey is a context key whose value WithContext adds to log lines
+type LogContextKey string
+
+const (
+	RequestIDKey LogContextKey = "request_id"
+	TraceIDKey   LogContextKey = "trace_id"
+	UserIDKey    LogContextKey = "user_id"
+)
+
+// DefaultLogContextKeys are the keys WithContext looks for unless others are
+// set with WithContextKeys
+var DefaultLogContextKeys = []LogContextKey{RequestIDKey, TraceIDKey, UserIDKey}
+
 type LoggingClient struct {
-	scopes map[string]string
-	sugar  *zap.SugaredLogger
+	scopes      map[string]string
+	sugar       *zap.SugaredLogger
+	contextKeys []LogContextKey
 }
 
 // NewLogClient initializes a new LoggingClient with optional scopes
 func NewLogClient(scopes map[string]string) *LoggingClient {
-	logger, _ := zap.NewProduction()
-	sugar := logger.Sugar()
+	return NewLogClientWithLevel(scopes, zapcore.InfoLevel)
+}
+
+// NewLogClientWithLevel initializes a new LoggingClient that only logs
+// messages at or above level
+func NewLogClientWithLevel(scopes map[string]string, level zapcore.Level) *LoggingClient {
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(level)
+	logger, err := cfg.Build()
+	if err != nil {
+		logger = zap.NewNop()
+	}
 	return &LoggingClient{
-		scopes: scopes,
-		sugar:  sugar,
+		scopes:      scopes,
+		sugar:       logger.Sugar(),
+		contextKeys: DefaultLogContextKeys,
+	}
+}
+
+// ParseLogLevel parses a level name such as "debug", "info", "warn" or
+// "error", e.g. from an environment variable
+func ParseLogLevel(level string) (zapcore.Level, error) {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return zapcore.InfoLevel, fmt.Errorf("invalid log level %q: %w", level, err)
 	}
+	return parsed, nil
 }
 
 // Named creates a new Logger with additional or updated scopes
@@ -39,9 +79,46 @@ func (l *LoggingClient) Named(scopes map[string]string) Logger {
 		newScopes[k] = v
 	}
 	return &LoggingClient{
-		scopes: newScopes,
-		sugar:  l.sugar,
+		scopes:      newScopes,
+		sugar:       l.sugar,
+		contextKeys: l.contextKeys,
+	}
+}
+
+// WithContextKeys returns a copy of the client whose WithContext looks for
+// keys instead of DefaultLogContextKeys
+func (l *LoggingClient) WithContextKeys(keys ...LogContextKey) *LoggingClient {
+	return &LoggingClient{
+		scopes:      l.scopes,
+		sugar:       l.sugar,
+		contextKeys: keys,
+	}
+}
+
+// WithContext returns a Logger scoped with the values of the context keys
+// found in ctx. A value is found whether it was stored under the
+// LogContextKey or under the plain string, as gin's c.Set does
+func (l *LoggingClient) WithContext(ctx context.Context) Logger {
+	scopes := contextScopes(ctx, l.contextKeys)
+	if len(scopes) == 0 {
+		return l
+	}
+	return l.Named(scopes)
+}
+
+// contextScopes returns the values of keys found in ctx, keyed by name
+func contextScopes(ctx context.Context, keys []LogContextKey) map[string]string {
+	scopes := make(map[string]string)
+	for _, key := range keys {
+		value := ctx.Value(key)
+		if value == nil {
+			value = ctx.Value(string(key))
+		}
+		if value != nil {
+			scopes[string(key)] = fmt.Sprint(value)
+		}
 	}
+	return scopes
 }
 
 // Info logs an informational message
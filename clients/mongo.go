@@ -2,10 +2,18 @@ package clients
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -13,35 +21,109 @@ type MongoIndexView interface {
 	CreateOne(ctx context.Context, model mongo.IndexModel) (string, error)
 }
 
+// IndexSpec declares an index by its keys and commonly used options.
+// Use Model to pass it to MongoIndexView.CreateOne.
+type IndexSpec struct {
+	Keys   bson.D
+	Name   string
+	Unique bool
+	Sparse bool
+
+	// Collation controls string comparison for the index, e.g.
+	// &options.Collation{Locale: "en", Strength: 2} for case-insensitive matching.
+	// Queries must specify the same collation to use the index.
+	Collation *options.Collation
+
+	// PartialFilterExpression limits the index to documents matching the
+	// filter, e.g. bson.M{"status": "active"}.
+	PartialFilterExpression interface{}
+}
+
+// Model converts the spec to a driver index model.
+func (s IndexSpec) Model() mongo.IndexModel {
+	opts := options.Index()
+	if s.Name != "" {
+		opts.SetName(s.Name)
+	}
+	if s.Unique {
+		opts.SetUnique(true)
+	}
+	if s.Sparse {
+		opts.SetSparse(true)
+	}
+	if s.Collation != nil {
+		opts.SetCollation(s.Collation)
+	}
+	if s.PartialFilterExpression != nil {
+		opts.SetPartialFilterExpression(s.PartialFilterExpression)
+	}
+	return mongo.IndexModel{
+		Keys:    s.Keys,
+		Options: opts,
+	}
+}
+
 type MongoCollection interface {
 	InsertOne(ctx context.Context, document interface{}) error
+	InsertOneID(ctx context.Context, document interface{}) (interface{}, error)
 	InsertMany(ctx context.Context, documents []interface{}) error
 	FindOne(ctx context.Context, filter interface{}, result interface{}) error
 	Find(ctx context.Context, filter interface{}, results interface{}, options ...*options.FindOptions) error
 	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
-	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult
+	Upsert(ctx context.Context, filter interface{}, update interface{}) (*mongo.UpdateResult, error)
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, result interface{}, opts ...*options.FindOneAndUpdateOptions) error
 	UpdateMany(ctx context.Context, filter interface{}, update interface{}) (*mongo.UpdateResult, error)
 	DeleteOne(ctx context.Context, filter interface{}) (*mongo.DeleteResult, error)
 	DeleteMany(ctx context.Context, filter interface{}) (*mongo.DeleteResult, error)
-	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}) error
+	ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error)
+	BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
 
 	Indexes() MongoIndexView
+	CreateIndex(ctx context.Context, keys bson.D, unique bool) (string, error)
+	EnsureIndexes(ctx context.Context, models []mongo.IndexModel) error
 	Exists(ctx context.Context, filter interface{}) (bool, error)
+	Count(ctx context.Context, filter interface{}) (int64, error)
+	Distinct(ctx context.Context, fieldName string, filter interface{}) ([]interface{}, error)
 	Aggregate(ctx context.Context, pipeline interface{}, results interface{}) error
+	AggregateToCollection(ctx context.Context, pipeline interface{}) error
+	Watch(ctx context.Context, pipeline interface{}) (<-chan bson.M, <-chan error, error)
+}
+
+// MongoGridFS stores large files in a GridFS bucket.
+type MongoGridFS interface {
+	Upload(ctx context.Context, filename string, r io.Reader) (primitive.ObjectID, error)
+	Download(ctx context.Context, fileID interface{}, w io.Writer) error
+	Delete(ctx context.Context, fileID interface{}) error
 }
 
 type MongoClient interface {
 	Collection(database, collection string) MongoCollection
+	GridFS(database, bucket string) MongoGridFS
 	InsertOne(ctx context.Context, req *InsertOneRequest) error
+	InsertOneID(ctx context.Context, req *InsertOneRequest) (interface{}, error)
 	InsertMany(ctx context.Context, req *InsertManyRequest) error
 	FindOne(ctx context.Context, req *FindOneRequest, result interface{}) error
 	Find(ctx context.Context, req *FindRequest, results interface{}, options ...*options.FindOptions) error
+	FindPaginated(ctx context.Context, req *FindPaginatedRequest, results interface{}) (string, error)
 	Exists(ctx context.Context, req *ExistsRequest) (bool, error)
+	Count(ctx context.Context, req *CountRequest) (int64, error)
+	Distinct(ctx context.Context, req *DistinctRequest) ([]interface{}, error)
 	Aggregate(ctx context.Context, req *AggregateRequest, results interface{}) error
-	ReplaceOne(ctx context.Context, req *ReplaceOneRequest) error
+	AggregateToCollection(ctx context.Context, req *AggregateRequest) error
+	UpdateOne(ctx context.Context, req *UpdateOneRequest) (*mongo.UpdateResult, error)
+	ReplaceOne(ctx context.Context, req *ReplaceOneRequest) (*mongo.UpdateResult, error)
+	FindOneAndUpdate(ctx context.Context, req *FindOneAndUpdateRequest, result interface{}) error
+	UpdateWithVersion(ctx context.Context, req *UpdateWithVersionRequest, versionField string) error
+	BulkWrite(ctx context.Context, req *BulkWriteRequest, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Ping(ctx context.Context) error
 	Disconnect(ctx context.Context) error
 }
 
+// ErrVersionConflict is returned by UpdateWithVersion when the document was
+// modified by someone else since it was read. It wraps ErrConflict.
+var ErrVersionConflict = fmt.Errorf("mongo: document version %w", ErrConflict)
+
 // Concrete implementation
 type mongoCollection struct {
 	coll *mongo.Collection
@@ -53,8 +135,53 @@ func (c *mongoCollection) Indexes() MongoIndexView {
 	}
 }
 
+// IsNoDocumentsFound reports whether err is mongo.ErrNoDocuments. Such errors
+// also match ErrNotFound.
 func IsNoDocumentsFound(err error) bool {
-	return err == mongo.ErrNoDocuments
+	return errors.Is(err, mongo.ErrNoDocuments)
+}
+
+// mongoError wraps err with ErrNotFound, ErrConflict or ErrTimeout where one
+// applies.
+func mongoError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case mongo.IsDuplicateKeyError(err):
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	case mongo.IsTimeout(err):
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
+
+// CreateIndex creates an index on keys, e.g. bson.D{{Key: "email", Value: 1}},
+// and returns its name. Creating an index that already exists with the same
+// keys and options succeeds.
+func (c *mongoCollection) CreateIndex(ctx context.Context, keys bson.D, unique bool) (string, error) {
+	return c.Indexes().CreateOne(ctx, IndexSpec{Keys: keys, Unique: unique}.Model())
+}
+
+// indexExistsCode is the server's IndexAlreadyExists error code.
+const indexExistsCode = 68
+
+// EnsureIndexes creates each index that does not exist yet, so it can be
+// called on every startup. An index that exists with the same name but
+// different keys or options is still an error.
+func (c *mongoCollection) EnsureIndexes(ctx context.Context, models []mongo.IndexModel) error {
+	indexes := c.Indexes()
+	for _, model := range models {
+		if _, err := indexes.CreateOne(ctx, model); err != nil {
+			var serverErr mongo.ServerError
+			if errors.As(err, &serverErr) && serverErr.HasErrorCode(indexExistsCode) {
+				continue
+			}
+			return fmt.Errorf("failed to create index %v: %w", model.Keys, err)
+		}
+	}
+	return nil
 }
 
 // Implementation for indexes
@@ -67,65 +194,255 @@ func (iv *mongoIndexView) CreateOne(ctx context.Context, model mongo.IndexModel)
 }
 
 func (c *mongoCollection) InsertOne(ctx context.Context, document interface{}) error {
-	_, err := c.coll.InsertOne(ctx, document)
+	_, err := c.InsertOneID(ctx, document)
 	return err
 }
 
+// InsertOneID inserts document and returns its _id, e.g. the ObjectID
+// generated for a document without one.
+func (c *mongoCollection) InsertOneID(ctx context.Context, document interface{}) (interface{}, error) {
+	result, err := c.coll.InsertOne(ctx, document)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+	return result.InsertedID, nil
+}
+
 func (c *mongoCollection) InsertMany(ctx context.Context, documents []interface{}) error {
 	_, err := c.coll.InsertMany(ctx, documents)
-	return err
+	return mongoError(err)
 }
 
 func (c *mongoCollection) FindOne(ctx context.Context, filter interface{}, result interface{}) error {
-	return c.coll.FindOne(ctx, filter).Decode(result)
+	return mongoError(c.coll.FindOne(ctx, filter).Decode(result))
 }
 
 func (c *mongoCollection) Find(ctx context.Context, filter interface{}, results interface{}, opts ...*options.FindOptions) error {
 	cursor, err := c.coll.Find(ctx, filter, opts...)
 	if err != nil {
-		return err
+		return mongoError(err)
 	}
 	defer cursor.Close(ctx)
-	return cursor.All(ctx, results)
+	return mongoError(cursor.All(ctx, results))
+}
+
+// AggregateToCollection runs a pipeline ending in a $merge or $out stage,
+// which writes its results to a collection instead of returning them.
+func (c *mongoCollection) AggregateToCollection(ctx context.Context, pipeline interface{}) error {
+	cursor, err := c.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return mongoError(err)
+	}
+	return mongoError(cursor.Close(ctx))
 }
 
 func (c *mongoCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
-	return c.coll.UpdateOne(ctx, filter, update, opts...)
+	result, err := c.coll.UpdateOne(ctx, filter, update, opts...)
+	return result, mongoError(err)
+}
+
+// Upsert applies the update to the first document matching the filter, or
+// inserts a new document if none matches. The result's UpsertedID is set when
+// a document was inserted.
+func (c *mongoCollection) Upsert(ctx context.Context, filter interface{}, update interface{}) (*mongo.UpdateResult, error) {
+	return c.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
 }
 
-func (c *mongoCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
-	return c.coll.FindOneAndUpdate(ctx, filter, update, opts...)
+// FindOneAndUpdate atomically updates a single document and decodes it into result.
+// By default the document is returned as it was before the update; pass
+// options.FindOneAndUpdate().SetReturnDocument(options.After) to read the updated state.
+func (c *mongoCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, result interface{}, opts ...*options.FindOneAndUpdateOptions) error {
+	return mongoError(c.coll.FindOneAndUpdate(ctx, filter, update, opts...).Decode(result))
 }
 
 func (c *mongoCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}) (*mongo.UpdateResult, error) {
-	return c.coll.UpdateMany(ctx, filter, update)
+	result, err := c.coll.UpdateMany(ctx, filter, update)
+	return result, mongoError(err)
 }
 
 func (c *mongoCollection) DeleteOne(ctx context.Context, filter interface{}) (*mongo.DeleteResult, error) {
-	return c.coll.DeleteOne(ctx, filter)
+	result, err := c.coll.DeleteOne(ctx, filter)
+	return result, mongoError(err)
 }
 
 func (c *mongoCollection) DeleteMany(ctx context.Context, filter interface{}) (*mongo.DeleteResult, error) {
-	return c.coll.DeleteMany(ctx, filter)
+	result, err := c.coll.DeleteMany(ctx, filter)
+	return result, mongoError(err)
 }
 
-func (c *mongoCollection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}) error {
-	_, err := c.coll.ReplaceOne(ctx, filter, replacement)
-	return err
+func (c *mongoCollection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	result, err := c.coll.ReplaceOne(ctx, filter, replacement, opts...)
+	return result, mongoError(err)
+}
+
+// BulkWrite executes a batch of mixed write operations. Use
+// options.BulkWrite().SetOrdered(false) to let the server continue past failures.
+func (c *mongoCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	result, err := c.coll.BulkWrite(ctx, models, opts...)
+	return result, mongoError(err)
 }
 
 func (c *mongoCollection) Exists(ctx context.Context, filter interface{}) (bool, error) {
 	count, err := c.coll.CountDocuments(ctx, filter)
-	return count > 0, err
+	return count > 0, mongoError(err)
+}
+
+func (c *mongoCollection) Count(ctx context.Context, filter interface{}) (int64, error) {
+	count, err := c.coll.CountDocuments(ctx, filter)
+	return count, mongoError(err)
+}
+
+func (c *mongoCollection) Distinct(ctx context.Context, fieldName string, filter interface{}) ([]interface{}, error) {
+	if filter == nil {
+		filter = bson.M{}
+	}
+	values, err := c.coll.Distinct(ctx, fieldName, filter)
+	return values, mongoError(err)
 }
 
 func (c *mongoCollection) Aggregate(ctx context.Context, pipeline interface{}, results interface{}) error {
 	cursor, err := c.coll.Aggregate(ctx, pipeline)
 	if err != nil {
-		return err
+		return mongoError(err)
 	}
 	defer cursor.Close(ctx)
-	return cursor.All(ctx, results)
+	return mongoError(cursor.All(ctx, results))
+}
+
+// watchRetryDelay is how long Watch waits before reopening a failed change stream.
+const watchRetryDelay = time.Second
+
+// Watch opens a change stream on the collection and sends each decoded change
+// event to the returned event channel. If the stream fails with a resumable
+// error, it is reopened after the last seen resume token so no events are
+// missed. Any other error, e.g. an invalidated stream or lost history, ends
+// the stream: the error is sent to the error channel and both channels are
+// closed. When ctx is cancelled, both channels are closed without an error.
+func (c *mongoCollection) Watch(ctx context.Context, pipeline interface{}) (<-chan bson.M, <-chan error, error) {
+	if pipeline == nil {
+		pipeline = mongo.Pipeline{}
+	}
+	stream, err := c.coll.Watch(ctx, pipeline)
+	if err != nil {
+		return nil, nil, mongoError(err)
+	}
+
+	events := make(chan bson.M)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(events)
+		if err := c.watch(ctx, stream, pipeline, events); err != nil && ctx.Err() == nil {
+			errs <- mongoError(err)
+		}
+	}()
+
+	return events, errs, nil
+}
+
+// watch sends the events of stream to events, reopening it after resumable
+// errors, until ctx is cancelled or the stream fails for good.
+func (c *mongoCollection) watch(ctx context.Context, stream *mongo.ChangeStream, pipeline interface{}, events chan<- bson.M) error {
+	for {
+		for stream.Next(ctx) {
+			var event bson.M
+			if err := stream.Decode(&event); err != nil {
+				stream.Close(context.Background())
+				return fmt.Errorf("failed to decode change event: %w", err)
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		}
+		err := stream.Err()
+		token := stream.ResumeToken()
+		stream.Close(context.Background())
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil {
+			return errors.New("change stream closed by the server")
+		}
+		if !resumableChangeStreamError(err) {
+			return err
+		}
+
+		// Reopen the stream from the last resume token until it succeeds,
+		// fails for good or the context is cancelled.
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(watchRetryDelay):
+			}
+			opts := options.ChangeStream()
+			if token != nil {
+				opts.SetResumeAfter(token)
+			}
+			if stream, err = c.coll.Watch(ctx, pipeline, opts); err == nil {
+				break
+			}
+			if !resumableChangeStreamError(err) {
+				return err
+			}
+		}
+	}
+}
+
+// resumableChangeStreamError reports whether a change stream that failed with
+// err can be reopened from its resume token.
+func resumableChangeStreamError(err error) bool {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		return serverErr.HasErrorLabel("ResumableChangeStreamError")
+	}
+	return mongo.IsNetworkError(err) || mongo.IsTimeout(err)
+}
+
+// Implementation for GridFS buckets
+type mongoGridFS struct {
+	db   *mongo.Database
+	name string
+}
+
+// bucket opens the GridFS bucket. The driver's bucket API has no context
+// parameters, so the context deadline, if any, is applied to the bucket.
+func (g *mongoGridFS) bucket(ctx context.Context) (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(g.db, options.GridFSBucket().SetName(g.name))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetReadDeadline(deadline)
+		bucket.SetWriteDeadline(deadline)
+	}
+	return bucket, nil
+}
+
+func (g *mongoGridFS) Upload(ctx context.Context, filename string, r io.Reader) (primitive.ObjectID, error) {
+	bucket, err := g.bucket(ctx)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return bucket.UploadFromStream(filename, r)
+}
+
+func (g *mongoGridFS) Download(ctx context.Context, fileID interface{}, w io.Writer) error {
+	bucket, err := g.bucket(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = bucket.DownloadToStream(fileID, w)
+	return err
+}
+
+func (g *mongoGridFS) Delete(ctx context.Context, fileID interface{}) error {
+	bucket, err := g.bucket(ctx)
+	if err != nil {
+		return err
+	}
+	return bucket.DeleteContext(ctx, fileID)
 }
 
 type mongoClient struct {
@@ -138,10 +455,27 @@ func (c *mongoClient) Collection(database, collection string) MongoCollection {
 	}
 }
 
+// GridFS returns the named GridFS bucket in the database. An empty bucket name
+// uses the driver's default "fs" bucket.
+func (c *mongoClient) GridFS(database, bucket string) MongoGridFS {
+	if bucket == "" {
+		bucket = options.DefaultName
+	}
+	return &mongoGridFS{
+		db:   c.client.Database(database),
+		name: bucket,
+	}
+}
+
 func (c *mongoClient) InsertOne(ctx context.Context, req *InsertOneRequest) error {
 	return c.Collection(req.Database, req.Collection).InsertOne(ctx, req.Document)
 }
 
+// InsertOneID inserts the request's document and returns its _id.
+func (c *mongoClient) InsertOneID(ctx context.Context, req *InsertOneRequest) (interface{}, error) {
+	return c.Collection(req.Database, req.Collection).InsertOneID(ctx, req.Document)
+}
+
 func (c *mongoClient) InsertMany(ctx context.Context, req *InsertManyRequest) error {
 	return c.Collection(req.Database, req.Collection).InsertMany(ctx, req.Documents)
 }
@@ -175,35 +509,269 @@ func (c *mongoClient) Find(ctx context.Context, req *FindRequest, results interf
 	return c.Collection(req.Database, req.Collection).Find(ctx, req.Filter, results, opt)
 }
 
-func (c *mongoClient) ReplaceOne(ctx context.Context, req *ReplaceOneRequest) error {
-	return c.Collection(req.Database, req.Collection).ReplaceOne(ctx, req.Filter, req.Replacement)
+// FindPaginated returns one page of documents ordered by req.SortField, starting
+// after the cursor in req.After (empty for the first page). It returns the cursor
+// for the next page, or an empty string when this is the last page. The sort
+// field should be unique, e.g. _id, for pages to be stable.
+func (c *mongoClient) FindPaginated(ctx context.Context, req *FindPaginatedRequest, results interface{}) (string, error) {
+	filter := req.Filter
+	if req.After != "" {
+		after, err := decodePageCursor(req.After)
+		if err != nil {
+			return "", err
+		}
+		afterFilter := bson.M{req.SortField: bson.M{"$gt": after}}
+		if filter == nil {
+			filter = afterFilter
+		} else {
+			filter = bson.M{"$and": bson.A{filter, afterFilter}}
+		}
+	}
+	if filter == nil {
+		filter = bson.M{}
+	}
+
+	opt := options.Find().SetSort(bson.D{{Key: req.SortField, Value: 1}})
+	if req.Limit > 0 {
+		// Fetch one extra document to know whether another page exists.
+		opt.SetLimit(req.Limit + 1)
+	}
+
+	var docs []bson.Raw
+	if err := c.Collection(req.Database, req.Collection).Find(ctx, filter, &docs, opt); err != nil {
+		return "", err
+	}
+
+	next := ""
+	if req.Limit > 0 && int64(len(docs)) > req.Limit {
+		docs = docs[:req.Limit]
+		last, err := docs[len(docs)-1].LookupErr(strings.Split(req.SortField, ".")...)
+		if err != nil {
+			return "", fmt.Errorf("failed to read sort field %s: %w", req.SortField, err)
+		}
+		if next, err = encodePageCursor(last); err != nil {
+			return "", err
+		}
+	}
+
+	return next, decodeRawDocuments(docs, results)
+}
+
+type pageCursor struct {
+	Value bson.RawValue `bson:"v"`
+}
+
+func encodePageCursor(value bson.RawValue) (string, error) {
+	data, err := bson.Marshal(pageCursor{Value: value})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode page cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageCursor(cursor string) (bson.RawValue, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return bson.RawValue{}, fmt.Errorf("invalid page cursor: %w", err)
+	}
+	var pc pageCursor
+	if err := bson.Unmarshal(data, &pc); err != nil {
+		return bson.RawValue{}, fmt.Errorf("invalid page cursor: %w", err)
+	}
+	return pc.Value, nil
+}
+
+// decodeRawDocuments decodes docs into results, which must be a pointer to a slice.
+func decodeRawDocuments(docs []bson.Raw, results interface{}) error {
+	rv := reflect.ValueOf(results)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("results must be a pointer to a slice, got %T", results)
+	}
+	slice := reflect.MakeSlice(rv.Elem().Type(), 0, len(docs))
+	elemType := slice.Type().Elem()
+	for _, doc := range docs {
+		elem := reflect.New(elemType)
+		if err := bson.Unmarshal(doc, elem.Interface()); err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, elem.Elem())
+	}
+	rv.Elem().Set(slice)
+	return nil
+}
+
+// UpdateOne applies the update to the first document matching the filter. With
+// Upsert set, a document is inserted if none matches.
+func (c *mongoClient) UpdateOne(ctx context.Context, req *UpdateOneRequest) (*mongo.UpdateResult, error) {
+	return c.Collection(req.Database, req.Collection).UpdateOne(ctx, req.Filter, req.Update, options.Update().SetUpsert(req.Upsert))
+}
+
+// ReplaceOne replaces the first document matching the filter. With Upsert
+// set, the replacement is inserted if none matches.
+func (c *mongoClient) ReplaceOne(ctx context.Context, req *ReplaceOneRequest) (*mongo.UpdateResult, error) {
+	return c.Collection(req.Database, req.Collection).ReplaceOne(ctx, req.Filter, req.Replacement, options.Replace().SetUpsert(req.Upsert))
+}
+
+func (c *mongoClient) FindOneAndUpdate(ctx context.Context, req *FindOneAndUpdateRequest, result interface{}) error {
+	opt := options.FindOneAndUpdate().SetUpsert(req.Upsert)
+	if req.ReturnAfter {
+		opt.SetReturnDocument(options.After)
+	}
+	return c.Collection(req.Database, req.Collection).FindOneAndUpdate(ctx, req.Filter, req.Update, result, opt)
+}
+
+// UpdateWithVersion applies the update only if the document's versionField still
+// equals req.Version, and increments the version as part of the same update.
+func (c *mongoClient) UpdateWithVersion(ctx context.Context, req *UpdateWithVersionRequest, versionField string) error {
+	filter := bson.M{versionField: req.Version}
+	if req.Filter != nil {
+		filter = bson.M{"$and": bson.A{req.Filter, filter}}
+	}
+
+	update := bson.M{}
+	for k, v := range req.Update {
+		update[k] = v
+	}
+	inc := bson.M{}
+	switch existing := update["$inc"].(type) {
+	case bson.M:
+		for k, v := range existing {
+			inc[k] = v
+		}
+	case map[string]interface{}:
+		for k, v := range existing {
+			inc[k] = v
+		}
+	case bson.D:
+		for _, e := range existing {
+			inc[e.Key] = e.Value
+		}
+	}
+	inc[versionField] = 1
+	update["$inc"] = inc
+
+	result, err := c.Collection(req.Database, req.Collection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+func (c *mongoClient) BulkWrite(ctx context.Context, req *BulkWriteRequest, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	return c.Collection(req.Database, req.Collection).BulkWrite(ctx, req.Models, opts...)
 }
 
 func (c *mongoClient) Exists(ctx context.Context, req *ExistsRequest) (bool, error) {
 	return c.Collection(req.Database, req.Collection).Exists(ctx, req.Filter)
 }
 
+func (c *mongoClient) Count(ctx context.Context, req *CountRequest) (int64, error) {
+	return c.Collection(req.Database, req.Collection).Count(ctx, req.Filter)
+}
+
+func (c *mongoClient) Distinct(ctx context.Context, req *DistinctRequest) ([]interface{}, error) {
+	return c.Collection(req.Database, req.Collection).Distinct(ctx, req.Field, req.Filter)
+}
+
 func (c *mongoClient) Aggregate(ctx context.Context, req *AggregateRequest, results interface{}) error {
 	return c.Collection(req.Database, req.Collection).Aggregate(ctx, req.Pipeline, results)
 }
 
+// AggregateToCollection runs a pipeline ending in a $merge or $out stage, e.g.
+// to materialize a rollup, without decoding any results.
+func (c *mongoClient) AggregateToCollection(ctx context.Context, req *AggregateRequest) error {
+	return c.Collection(req.Database, req.Collection).AggregateToCollection(ctx, req.Pipeline)
+}
+
+// WithTransaction runs fn inside a multi-document transaction. The context passed
+// to fn carries the session, so collection operations using it join the
+// transaction. The transaction is committed when fn returns nil and aborted
+// otherwise. Transient transaction errors and unknown commit results are
+// retried by the driver, so fn may be called more than once.
+func (c *mongoClient) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := c.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start mongo session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+// Ping verifies the primary is reachable.
+func (c *mongoClient) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx, nil)
+}
+
 func (c *mongoClient) Disconnect(ctx context.Context) error {
 	return c.client.Disconnect(ctx)
 }
 
+// MongoConfig configures a MongoDB client.
+type MongoConfig struct {
+	// URI is the connection string. It may contain two %s verbs that are
+	// filled with Username and Password.
+	URI      string
+	Username string
+	Password string
+
+	// MaxPoolSize is the maximum number of connections in the pool. Zero uses the driver default.
+	MaxPoolSize uint64
+	// ConnectTimeout bounds how long establishing a connection may take. Zero uses the driver default.
+	ConnectTimeout time.Duration
+	// ServerSelectionTimeout bounds how long an operation waits for a suitable
+	// server. Zero uses the driver default.
+	ServerSelectionTimeout time.Duration
+}
+
 func NewMongoClient(ctx context.Context, uri, username, password string) MongoClient {
+	client, err := NewMongoClientWithOptions(ctx, MongoConfig{
+		URI:      uri,
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+	return client
+}
+
+// NewMongoClientWithOptions connects to MongoDB using cfg and verifies the
+// connection with a ping. Unlike NewMongoClient it returns an error instead
+// of panicking.
+func NewMongoClientWithOptions(ctx context.Context, cfg MongoConfig) (MongoClient, error) {
 	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
-	fmt.Println("Using MONGO_URI: ", uri)
-	uriString := fmt.Sprintf(uri, username, password)
+	fmt.Println("Using MONGO_URI: ", cfg.URI)
+	uriString := cfg.URI
+	if strings.Contains(cfg.URI, "%s") {
+		uriString = fmt.Sprintf(cfg.URI, cfg.Username, cfg.Password)
+	}
 	opts := options.Client().ApplyURI(uriString).SetServerAPIOptions(serverAPI)
-	client, err := mongo.Connect(context.TODO(), opts)
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.ConnectTimeout > 0 {
+		opts.SetConnectTimeout(cfg.ConnectTimeout)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
-		panic("Failed to connect to MongoDB: " + err.Error())
+		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
-	err = client.Database("admin").RunCommand(context.TODO(), bson.D{{Key: "ping", Value: 1}}).Err()
+	err = client.Database("admin").RunCommand(ctx, bson.D{{Key: "ping", Value: 1}}).Err()
 	if err != nil {
-		panic("Failed to ping MongoDB: " + err.Error())
+		client.Disconnect(context.Background())
+		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
 	}
 	fmt.Println("Connected to MongoDB")
-	return &mongoClient{client}
+	return &mongoClient{client}, nil
 }
@@ -0,0 +1,73 @@
+package clients
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Pipeline builds an aggregation pipeline stage by stage, e.g.
+//
+//	pipeline := NewPipeline().
+//		Match(bson.M{"status": "paid"}).
+//		Group("$customerId", bson.D{{Key: "total", Value: bson.M{"$sum": "$amount"}}}).
+//		Sort(bson.D{{Key: "total", Value: -1}}).
+//		Limit(10).
+//		Build()
+//
+// Stages run in the order they are added.
+type Pipeline struct {
+	stages mongo.Pipeline
+}
+
+// NewPipeline creates an empty pipeline.
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+func (p *Pipeline) stage(name string, value interface{}) *Pipeline {
+	p.stages = append(p.stages, bson.D{{Key: name, Value: value}})
+	return p
+}
+
+// Match adds a $match stage keeping the documents that match filter.
+func (p *Pipeline) Match(filter interface{}) *Pipeline {
+	return p.stage("$match", filter)
+}
+
+// Group adds a $group stage grouping documents by id, e.g. "$field", with the
+// given accumulator fields.
+func (p *Pipeline) Group(id interface{}, fields bson.D) *Pipeline {
+	group := append(bson.D{{Key: "_id", Value: id}}, fields...)
+	return p.stage("$group", group)
+}
+
+// Sort adds a $sort stage. Use a bson.D to sort by several fields in order.
+func (p *Pipeline) Sort(sort interface{}) *Pipeline {
+	return p.stage("$sort", sort)
+}
+
+// Limit adds a $limit stage passing on at most n documents.
+func (p *Pipeline) Limit(n int64) *Pipeline {
+	return p.stage("$limit", n)
+}
+
+// Project adds a $project stage reshaping documents with projection.
+func (p *Pipeline) Project(projection interface{}) *Pipeline {
+	return p.stage("$project", projection)
+}
+
+// Lookup adds a $lookup stage joining the documents of the from collection
+// whose foreignField equals localField, stored as an array in the as field.
+func (p *Pipeline) Lookup(from, localField, foreignField, as string) *Pipeline {
+	return p.stage("$lookup", bson.D{
+		{Key: "from", Value: from},
+		{Key: "localField", Value: localField},
+		{Key: "foreignField", Value: foreignField},
+		{Key: "as", Value: as},
+	})
+}
+
+// Build returns the pipeline, for use with Aggregate.
+func (p *Pipeline) Build() mongo.Pipeline {
+	return p.stages
+}
@@ -0,0 +1,366 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// postgresDatabase adapts a PostgresClient to DatabaseClient. A request's
+// Collection names the table and its Database, if set, the schema.
+//
+// Documents are structs or maps. Struct fields map to the column named by
+// their `db` tag, or to their lowercased name; fields tagged `db:"-"` are
+// skipped. Filters are maps of column to value, matched with equality.
+type postgresDatabase struct {
+	client PostgresClient
+}
+
+// NewPostgresDatabaseClient creates a DatabaseClient storing documents as rows
+// in Postgres tables, so code written against DatabaseClient can run on
+// Postgres. Only equality filters are supported.
+func NewPostgresDatabaseClient(client PostgresClient) DatabaseClient {
+	return &postgresDatabase{client: client}
+}
+
+func (d *postgresDatabase) InsertOne(ctx context.Context, req *InsertOneRequest) error {
+	return d.insert(ctx, req.Database, req.Collection, req.Document)
+}
+
+func (d *postgresDatabase) InsertMany(ctx context.Context, req *InsertManyRequest) error {
+	if len(req.Documents) == 0 {
+		return nil
+	}
+	batch := &pgx.Batch{}
+	for _, doc := range req.Documents {
+		sql, args, err := insertSQL(req.Database, req.Collection, doc)
+		if err != nil {
+			return err
+		}
+		batch.Queue(sql, args...)
+	}
+	results, err := d.client.SendBatch(ctx, batch)
+	if err != nil {
+		return err
+	}
+	for range req.Documents {
+		if _, err := results.Exec(); err != nil {
+			results.Close()
+			return err
+		}
+	}
+	return results.Close()
+}
+
+func (d *postgresDatabase) insert(ctx context.Context, schema, table string, doc interface{}) error {
+	sql, args, err := insertSQL(schema, table, doc)
+	if err != nil {
+		return err
+	}
+	_, err = d.client.Exec(ctx, sql, args...)
+	return err
+}
+
+// FindOne scans the first row matching the filter into result, a pointer to a
+// struct or map. If no row matches, it returns an error matching ErrNotFound
+// and pgx.ErrNoRows.
+func (d *postgresDatabase) FindOne(ctx context.Context, req *FindOneRequest, result interface{}) error {
+	where, args, err := whereSQL(req.Filter)
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("SELECT * FROM %s%s LIMIT 1", qualifiedTable(req.Database, req.Collection), where)
+
+	rows, err := d.client.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return postgresError(pgx.ErrNoRows)
+	}
+	if err := scanRow(rows, reflect.ValueOf(result)); err != nil {
+		return err
+	}
+	rows.Close()
+	return rows.Err()
+}
+
+// Find scans the rows matching the filter into results, a pointer to a slice
+// of structs, struct pointers or maps. Sort may be a bson.D or a map of column
+// to 1 (ascending) or -1 (descending).
+func (d *postgresDatabase) Find(ctx context.Context, req *FindRequest, results interface{}) error {
+	slice := reflect.ValueOf(results)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("results must be a pointer to a slice, got %T", results)
+	}
+	slice = slice.Elem()
+
+	where, args, err := whereSQL(req.Filter)
+	if err != nil {
+		return err
+	}
+	orderBy, err := orderBySQL(req.Sort)
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("SELECT * FROM %s%s%s", qualifiedTable(req.Database, req.Collection), where, orderBy)
+	if req.Limit > 0 {
+		sql += fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+	if req.Skip > 0 {
+		sql += fmt.Sprintf(" OFFSET %d", req.Skip)
+	}
+
+	rows, err := d.client.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	items := reflect.MakeSlice(slice.Type(), 0, 0)
+	elemType := slice.Type().Elem()
+	for rows.Next() {
+		item := reflect.New(elemType)
+		if err := scanRow(rows, item); err != nil {
+			return err
+		}
+		items = reflect.Append(items, item.Elem())
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	slice.Set(items)
+	return nil
+}
+
+func qualifiedTable(schema, table string) string {
+	if schema == "" {
+		return pgx.Identifier{table}.Sanitize()
+	}
+	return pgx.Identifier{schema, table}.Sanitize()
+}
+
+// insertSQL builds an INSERT of doc's columns into table.
+func insertSQL(schema, table string, doc interface{}) (string, []interface{}, error) {
+	columns, args, err := documentColumns(doc)
+	if err != nil {
+		return "", nil, err
+	}
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		qualifiedTable(schema, table),
+		quoteIdentifiers(columns),
+		strings.Join(placeholders, ", "),
+	)
+	return sql, args, nil
+}
+
+// documentColumns returns the columns and values of a struct or map document.
+func documentColumns(doc interface{}) ([]string, []interface{}, error) {
+	v := reflect.Indirect(reflect.ValueOf(doc))
+	switch v.Kind() {
+	case reflect.Map:
+		return mapColumns(v)
+	case reflect.Struct:
+		var columns []string
+		var values []interface{}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, ok := columnName(t.Field(i))
+			if !ok {
+				continue
+			}
+			columns = append(columns, name)
+			values = append(values, v.Field(i).Interface())
+		}
+		return columns, values, nil
+	default:
+		return nil, nil, fmt.Errorf("document must be a struct or map, got %T", doc)
+	}
+}
+
+// mapColumns returns a map's keys, sorted, and their values.
+func mapColumns(v reflect.Value) ([]string, []interface{}, error) {
+	if v.Type().Key().Kind() != reflect.String {
+		return nil, nil, fmt.Errorf("map keys must be strings, got %s", v.Type().Key())
+	}
+	columns := make([]string, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		columns = append(columns, key.String())
+	}
+	sort.Strings(columns)
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = v.MapIndex(reflect.ValueOf(column).Convert(v.Type().Key())).Interface()
+	}
+	return columns, values, nil
+}
+
+// columnName returns the column a struct field maps to, or false if it is skipped.
+func columnName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := strings.Split(field.Tag.Get("db"), ",")[0]
+	if tag == "-" {
+		return "", false
+	}
+	if tag != "" {
+		return tag, true
+	}
+	return strings.ToLower(field.Name), true
+}
+
+// whereSQL builds a WHERE clause matching each column of filter for equality.
+func whereSQL(filter interface{}) (string, []interface{}, error) {
+	if filter == nil {
+		return "", nil, nil
+	}
+	v := reflect.ValueOf(filter)
+	if v.Kind() != reflect.Map {
+		return "", nil, fmt.Errorf("filter must be a map, got %T", filter)
+	}
+	columns, values, err := mapColumns(v)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(columns) == 0 {
+		return "", nil, nil
+	}
+	conditions := make([]string, len(columns))
+	for i, column := range columns {
+		conditions[i] = fmt.Sprintf("%s = $%d", pgx.Identifier{column}.Sanitize(), i+1)
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), values, nil
+}
+
+// orderBySQL builds an ORDER BY clause from a bson.D or a map of column to direction.
+func orderBySQL(sortSpec interface{}) (string, error) {
+	if sortSpec == nil {
+		return "", nil
+	}
+	var keys bson.D
+	switch s := sortSpec.(type) {
+	case bson.D:
+		keys = s
+	default:
+		v := reflect.ValueOf(sortSpec)
+		if v.Kind() != reflect.Map {
+			return "", fmt.Errorf("sort must be a bson.D or map, got %T", sortSpec)
+		}
+		columns, values, err := mapColumns(v)
+		if err != nil {
+			return "", err
+		}
+		for i, column := range columns {
+			keys = append(keys, bson.E{Key: column, Value: values[i]})
+		}
+	}
+	if len(keys) == 0 {
+		return "", nil
+	}
+
+	terms := make([]string, len(keys))
+	for i, key := range keys {
+		direction := "ASC"
+		if fmt.Sprint(key.Value) == "-1" {
+			direction = "DESC"
+		}
+		terms[i] = pgx.Identifier{key.Key}.Sanitize() + " " + direction
+	}
+	return " ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// convertValue returns v as a value of type t, if it is assignable or
+// convertible to t. Numbers are not converted to strings, which Go would
+// interpret as code points.
+func convertValue(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Type().AssignableTo(t) {
+		return v, true
+	}
+	if t.Kind() == reflect.String && v.Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+	if v.Type().ConvertibleTo(t) {
+		return v.Convert(t), true
+	}
+	return reflect.Value{}, false
+}
+
+// scanRow scans the current row into dest, a pointer to a struct, a pointer
+// to a struct pointer, or a pointer to a map.
+func scanRow(rows pgx.Rows, dest reflect.Value) error {
+	if !dest.IsValid() {
+		return errors.New("result must be a non-nil pointer, got nil")
+	}
+	if dest.Kind() != reflect.Ptr || dest.IsNil() {
+		return fmt.Errorf("result must be a non-nil pointer, got %s", dest.Type())
+	}
+	target := dest.Elem()
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		target = target.Elem()
+	}
+
+	fields := rows.FieldDescriptions()
+	switch target.Kind() {
+	case reflect.Map:
+		values, err := rows.Values()
+		if err != nil {
+			return err
+		}
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(target.Type()))
+		}
+		keyType, elemType := target.Type().Key(), target.Type().Elem()
+		for i, field := range fields {
+			key, ok := convertValue(reflect.ValueOf(field.Name), keyType)
+			if !ok {
+				return fmt.Errorf("cannot use column name %s as a %s map key", field.Name, keyType)
+			}
+			value := reflect.Zero(elemType)
+			if values[i] != nil {
+				if value, ok = convertValue(reflect.ValueOf(values[i]), elemType); !ok {
+					return fmt.Errorf("cannot store column %s of type %T in a map of %s", field.Name, values[i], elemType)
+				}
+			}
+			target.SetMapIndex(key, value)
+		}
+		return nil
+	case reflect.Struct:
+		byColumn := make(map[string]int)
+		t := target.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if name, ok := columnName(t.Field(i)); ok {
+				byColumn[name] = i
+			}
+		}
+		scanTargets := make([]interface{}, len(fields))
+		for i, field := range fields {
+			if index, ok := byColumn[field.Name]; ok {
+				scanTargets[i] = target.Field(index).Addr().Interface()
+			} else {
+				scanTargets[i] = new(interface{})
+			}
+		}
+		return rows.Scan(scanTargets...)
+	default:
+		return fmt.Errorf("result must point to a struct or map, got %s", dest.Type())
+	}
+}
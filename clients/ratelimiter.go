@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills the bucket at KEYS[1] based on the time elapsed
+// since it was last used, then takes a token if one is available. It uses the
+// Redis server clock so replicas with skewed clocks agree. It returns whether
+// the request is allowed and, if not, how many milliseconds until a token is
+// available.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tokens, "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, retry}
+`)
+
+// RateLimiter is a token bucket rate limiter shared by every process using the
+// same Redis and key prefix. Each id gets its own bucket that holds up to burst
+// tokens and refills at rate tokens per second.
+type RateLimiter struct {
+	client RedisClient
+	prefix string
+	rate   float64
+	burst  int
+}
+
+// NewRateLimiter creates a rate limiter allowing rate requests per second per
+// id, with bursts of up to burst requests. A rate below one, e.g. 0.5, allows
+// a request every 1/rate seconds. Both rate and burst must be positive.
+func NewRateLimiter(client RedisClient, prefix string, rate float64, burst int) (*RateLimiter, error) {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		return nil, fmt.Errorf("rate limiter rate must be a positive number of requests per second, got %v", rate)
+	}
+	if burst <= 0 {
+		return nil, fmt.Errorf("rate limiter burst must be positive, got %d", burst)
+	}
+	return &RateLimiter{
+		client: client,
+		prefix: prefix,
+		rate:   rate,
+		burst:  burst,
+	}, nil
+}
+
+// Allow takes a token from id's bucket. When the bucket is empty it returns
+// false along with how long to wait before retrying, suitable for a
+// Retry-After header.
+func (l *RateLimiter) Allow(ctx context.Context, id string) (bool, time.Duration, error) {
+	result, err := tokenBucketScript.Run(ctx, l.client, []string{RedisID(l.prefix, id)}, l.rate, l.burst).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("redis rate limit error: %w", err)
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("unexpected result length: got %d, want 2", len(result))
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
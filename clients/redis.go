@@ -2,11 +2,18 @@ package clients
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/micahke/mirage/clients/cache"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -17,29 +24,88 @@ type RedisClient interface {
 	LPush(context context.Context, key string, values ...interface{}) *redis.IntCmd
 	BLPop(context context.Context, timeout time.Duration, keys ...string) *redis.StringSliceCmd
 	Del(context context.Context, keys ...string) *redis.IntCmd
+	Publish(context context.Context, channel string, message interface{}) *redis.IntCmd
+	Ping(context context.Context) *redis.StatusCmd
+	Close() error
+
+	// Scripting, used with redis.Script
+	Eval(context context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
+	EvalSha(context context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd
+	EvalRO(context context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
+	EvalShaRO(context context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd
+	ScriptExists(context context.Context, hashes ...string) *redis.BoolSliceCmd
+	ScriptLoad(context context.Context, script string) *redis.StringCmd
+}
+
+// Message is a message received from a Redis channel.
+type Message struct {
+	Channel string
+	Payload string
+}
+
+// Decode unmarshals the message's JSON payload into value.
+func (m Message) Decode(value interface{}) error {
+	if err := json.Unmarshal([]byte(m.Payload), value); err != nil {
+		return fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+	return nil
+}
+
+// RedisPipeline queues commands and sends them to Redis in a single round trip
+// when Exec is called. Values passed to Set are JSON-encoded like redisClient.Set,
+// so the string returned by a queued Get can be decoded with json.Unmarshal.
+type RedisPipeline interface {
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	Exec(ctx context.Context) ([]redis.Cmder, error)
+}
+
+// redisError describes a failed Redis command, marking timeouts with ErrTimeout.
+func redisError(op string, err error) error {
+	return fmt.Errorf("redis %s error: %w", op, wrapTimeout(err))
 }
 
 func RedisID(prefix string, id string) string {
 	return fmt.Sprintf("%s:%s", prefix, id)
 }
 
-var _ RedisClient = (*redis.Client)(nil)
+var (
+	_ RedisClient = (*redis.Client)(nil)
+	_ RedisClient = (*redis.ClusterClient)(nil)
+	_ cache.Cache = (*redisClient)(nil)
+)
 
 type redisClient struct {
-	client *redis.Client
+	client  redis.UniversalClient
+	cluster bool
+	loads   singleflight.Group
 }
 
 func NewRedisCacheClient(client *redis.Client) *redisClient {
 	return &redisClient{client: client}
 }
 
+// NewRedisClusterClient creates a client for a Redis Cluster. The addrs override
+// any addresses set in opts, which may be nil.
+func NewRedisClusterClient(addrs []string, opts *redis.ClusterOptions) *redisClient {
+	if opts == nil {
+		opts = &redis.ClusterOptions{}
+	}
+	opts.Addrs = addrs
+	return &redisClient{
+		client:  redis.NewClusterClient(opts),
+		cluster: true,
+	}
+}
+
 func (rc *redisClient) Get(ctx context.Context, key string, value interface{}) error {
 	result := rc.client.Get(ctx, key)
 	if err := result.Err(); err != nil {
 		if err == redis.Nil {
-			return fmt.Errorf("key %s not found", key)
+			return fmt.Errorf("key %s %w", key, ErrNotFound)
 		}
-		return fmt.Errorf("redis get error: %w", err)
+		return redisError("get", err)
 	}
 
 	jsonString, err := result.Result()
@@ -55,14 +121,9 @@ func (rc *redisClient) Get(ctx context.Context, key string, value interface{}) e
 }
 
 func (rc *redisClient) GetMany(ctx context.Context, keys []string, values interface{}) error {
-	result := rc.client.MGet(ctx, keys...)
-	if err := result.Err(); err != nil {
-		return fmt.Errorf("redis mget error: %w", err)
-	}
-
-	jsonStrings, err := result.Result()
+	jsonStrings, err := rc.mget(ctx, keys)
 	if err != nil {
-		return fmt.Errorf("failed to get result: %w", err)
+		return err
 	}
 
 	data, err := json.Marshal(jsonStrings)
@@ -77,20 +138,95 @@ func (rc *redisClient) GetMany(ctx context.Context, keys []string, values interf
 	return nil
 }
 
-func (rc *redisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+// mget fetches several keys at once. In cluster mode the keys may live in
+// different hash slots, which MGET rejects, so they are read with a pipeline
+// of GETs that the cluster client routes to the owning nodes.
+func (rc *redisClient) mget(ctx context.Context, keys []string) ([]interface{}, error) {
+	if !rc.cluster {
+		result, err := rc.client.MGet(ctx, keys...).Result()
+		if err != nil {
+			return nil, redisError("mget", err)
+		}
+		return result, nil
+	}
+
+	pipe := rc.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, redisError("mget", err)
+	}
+
+	// Exec reports only the first failed command, so check each one: a miss
+	// leaves a nil value, any other error fails the whole read.
+	values := make([]interface{}, len(keys))
+	for i, cmd := range cmds {
+		val, err := cmd.Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, redisError("mget", err)
+		}
+		values[i] = val
+	}
+	return values, nil
+}
+
+// marshalValue encodes a value as the JSON string stored in Redis.
+func marshalValue(value interface{}) (string, error) {
 	data, err := json.Marshal(value)
 	if err != nil {
-		return fmt.Errorf("failed to marshal value: %w", err)
+		return "", fmt.Errorf("failed to marshal value: %w", err)
+	}
+	return string(data), nil
+}
+
+func (rc *redisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	jsonString, err := marshalValue(value)
+	if err != nil {
+		return err
 	}
 
-	jsonString := string(data)
 	if err := rc.client.Set(ctx, key, jsonString, expiration).Err(); err != nil {
-		return fmt.Errorf("redis set error: %w", err)
+		return redisError("set", err)
 	}
 
 	return nil
 }
 
+// SetNX stores value at key only if the key does not exist, and reports
+// whether it was set. A zero ttl means the key never expires.
+func (rc *redisClient) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	jsonString, err := marshalValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := rc.client.SetNX(ctx, key, jsonString, ttl).Result()
+	if err != nil {
+		return false, redisError("setnx", err)
+	}
+	return set, nil
+}
+
+// SetXX stores value at key only if the key already exists, and reports
+// whether it was set. A zero ttl means the key never expires.
+func (rc *redisClient) SetXX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	jsonString, err := marshalValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	set, err := rc.client.SetXX(ctx, key, jsonString, ttl).Result()
+	if err != nil {
+		return false, redisError("setxx", err)
+	}
+	return set, nil
+}
+
 func (rc *redisClient) SetMany(ctx context.Context, keys []string, values []interface{}, expiration time.Duration) error {
 	if len(keys) != len(values) {
 		return fmt.Errorf("keys and values must be the same length")
@@ -98,25 +234,257 @@ func (rc *redisClient) SetMany(ctx context.Context, keys []string, values []inte
 
 	pipe := rc.client.Pipeline()
 	for i, key := range keys {
-		data, err := json.Marshal(values[i])
+		jsonString, err := marshalValue(values[i])
 		if err != nil {
-			return fmt.Errorf("failed to marshal value: %w", err)
+			return err
 		}
 
-		pipe.Set(ctx, key, string(data), expiration)
+		pipe.Set(ctx, key, jsonString, expiration)
 	}
 
 	_, err := pipe.Exec(ctx)
 	if err != nil {
-		return fmt.Errorf("redis mset error: %w", err)
+		return redisError("mset", err)
 	}
 
 	return nil
 }
 
+// HSet JSON-encodes value and stores it in field of the hash at key.
+func (rc *redisClient) HSet(ctx context.Context, key, field string, value interface{}) error {
+	jsonString, err := marshalValue(value)
+	if err != nil {
+		return err
+	}
+	if err := rc.client.HSet(ctx, key, field, jsonString).Err(); err != nil {
+		return redisError("hset", err)
+	}
+	return nil
+}
+
+// HGet reads field of the hash at key and unmarshals it into value.
+func (rc *redisClient) HGet(ctx context.Context, key, field string, value interface{}) error {
+	result, err := rc.client.HGet(ctx, key, field).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return fmt.Errorf("field %s of key %s %w", field, key, ErrNotFound)
+		}
+		return redisError("hget", err)
+	}
+
+	if err := json.Unmarshal([]byte(result), value); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	return nil
+}
+
+// HGetAll reads every field of the hash at key into value, which must be a
+// pointer to a map with string keys or to a struct. Struct fields are matched
+// by their json tag name, or by field name when untagged. Each field value is
+// JSON-decoded into the map's value type or the struct field's type.
+func (rc *redisClient) HGetAll(ctx context.Context, key string, value interface{}) error {
+	fields, err := rc.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return redisError("hgetall", err)
+	}
+	return decodeHash(fields, value)
+}
+
+func decodeHash(fields map[string]string, value interface{}) error {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("value must be a non-nil pointer, got %T", value)
+	}
+
+	target := rv.Elem()
+	switch target.Kind() {
+	case reflect.Map:
+		mapType := target.Type()
+		if mapType.Key().Kind() != reflect.String {
+			return fmt.Errorf("map key must be a string, got %s", mapType.Key())
+		}
+		if target.IsNil() {
+			target.Set(reflect.MakeMapWithSize(mapType, len(fields)))
+		}
+		for field, data := range fields {
+			elem := reflect.New(mapType.Elem())
+			if err := json.Unmarshal([]byte(data), elem.Interface()); err != nil {
+				return fmt.Errorf("failed to unmarshal field %s: %w", field, err)
+			}
+			target.SetMapIndex(reflect.ValueOf(field).Convert(mapType.Key()), elem.Elem())
+		}
+	case reflect.Struct:
+		structType := target.Type()
+		for i := 0; i < structType.NumField(); i++ {
+			sf := structType.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name := sf.Name
+			if tag, _, _ := strings.Cut(sf.Tag.Get("json"), ","); tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			data, ok := fields[name]
+			if !ok {
+				continue
+			}
+			if err := json.Unmarshal([]byte(data), target.Field(i).Addr().Interface()); err != nil {
+				return fmt.Errorf("failed to unmarshal field %s: %w", name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("value must point to a map or struct, got %T", value)
+	}
+	return nil
+}
+
+// Pipeline returns a new pipeline for batching commands.
+func (rc *redisClient) Pipeline() RedisPipeline {
+	return &redisPipeline{pipe: rc.client.Pipeline()}
+}
+
+type redisPipeline struct {
+	pipe redis.Pipeliner
+}
+
+func (p *redisPipeline) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	jsonString, err := marshalValue(value)
+	if err != nil {
+		return err
+	}
+	p.pipe.Set(ctx, key, jsonString, expiration)
+	return nil
+}
+
+func (p *redisPipeline) Get(ctx context.Context, key string) *redis.StringCmd {
+	return p.pipe.Get(ctx, key)
+}
+
+func (p *redisPipeline) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	return p.pipe.Del(ctx, keys...)
+}
+
+// Exec sends all queued commands. A missing key in a queued Get is reported on
+// that command rather than as an error from Exec.
+func (p *redisPipeline) Exec(ctx context.Context) ([]redis.Cmder, error) {
+	cmds, err := p.pipe.Exec(ctx)
+	if err != nil && err != redis.Nil {
+		return cmds, redisError("pipeline", err)
+	}
+	return cmds, nil
+}
+
+// Exists returns how many of the keys exist. In cluster mode the keys are
+// checked with a pipeline, since EXISTS rejects keys from different hash slots.
+func (rc *redisClient) Exists(ctx context.Context, keys ...string) (int64, error) {
+	if !rc.cluster {
+		n, err := rc.client.Exists(ctx, keys...).Result()
+		if err != nil {
+			return 0, redisError("exists", err)
+		}
+		return n, nil
+	}
+
+	pipe := rc.client.Pipeline()
+	cmds := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Exists(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, redisError("exists", err)
+	}
+
+	var n int64
+	for _, cmd := range cmds {
+		n += cmd.Val()
+	}
+	return n, nil
+}
+
+// GetOrSet decodes the value at key into dest. On a miss it calls compute,
+// caches the result for ttl and decodes it into dest. Concurrent misses for
+// the same key within this process share a single call to compute, which runs
+// with the context of the caller that started it. A compute error is returned
+// to every caller sharing the call and is not cached.
+func (rc *redisClient) GetOrSet(ctx context.Context, key string, dest interface{}, ttl time.Duration, compute func(context.Context) (interface{}, error)) error {
+	jsonString, err := rc.client.Get(ctx, key).Result()
+	if err != nil && err != redis.Nil {
+		return redisError("get", err)
+	}
+
+	if err == redis.Nil {
+		loaded, err, _ := rc.loads.Do(key, func() (interface{}, error) {
+			value, err := compute(ctx)
+			if err != nil {
+				return "", err
+			}
+			jsonString, err := marshalValue(value)
+			if err != nil {
+				return "", err
+			}
+			if err := rc.client.Set(ctx, key, jsonString, ttl).Err(); err != nil {
+				return "", redisError("set", err)
+			}
+			return jsonString, nil
+		})
+		if err != nil {
+			return err
+		}
+		jsonString = loaded.(string)
+	}
+
+	if err := json.Unmarshal([]byte(jsonString), dest); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	return nil
+}
+
 func (rc *redisClient) Delete(ctx context.Context, key string) error {
 	if err := rc.client.Del(ctx, key).Err(); err != nil {
-		return fmt.Errorf("redis del error: %w", err)
+		return redisError("del", err)
+	}
+	return nil
+}
+
+// DeleteMany deletes keys with a single DEL, or, on a cluster, where keys may
+// live on different nodes, one pipelined DEL per key.
+func (rc *redisClient) DeleteMany(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if !rc.cluster {
+		if err := rc.client.Del(ctx, keys...).Err(); err != nil {
+			return redisError("del", err)
+		}
+		return nil
+	}
+
+	pipe := rc.client.Pipeline()
+	for _, key := range keys {
+		pipe.Del(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return redisError("del", err)
+	}
+	return nil
+}
+
+// deleteBatch is the number of keys DeleteByPattern deletes per round trip.
+const deleteBatch = 500
+
+// DeleteByPattern deletes the keys matching pattern, found with SCAN.
+func (rc *redisClient) DeleteByPattern(ctx context.Context, pattern string) error {
+	keys, err := rc.ScanKeys(ctx, pattern)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(keys); start += deleteBatch {
+		end := min(start+deleteBatch, len(keys))
+		if err := rc.DeleteMany(ctx, keys[start:end]...); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -129,17 +497,118 @@ func (rc *redisClient) BLPop(context context.Context, timeout time.Duration, key
 	return rc.client.BLPop(context, timeout, keys...)
 }
 
+const (
+	// TTLNoExpiry is returned by TTL for a key that exists but never expires.
+	TTLNoExpiry time.Duration = -1
+	// TTLKeyMissing is returned by TTL for a key that does not exist.
+	TTLKeyMissing time.Duration = -2
+)
+
+// TTL returns the time left before key expires, TTLNoExpiry if it never
+// expires, or TTLKeyMissing if it does not exist.
+func (rc *redisClient) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := rc.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, redisError("pttl", err)
+	}
+	switch ttl {
+	case -1:
+		return TTLNoExpiry, nil
+	case -2:
+		return TTLKeyMissing, nil
+	}
+	return ttl, nil
+}
+
+// Expire sets key to expire after ttl, replacing any previous expiry, and
+// reports whether the key exists.
+func (rc *redisClient) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ok, err := rc.client.PExpire(ctx, key, ttl).Result()
+	if err != nil {
+		return false, redisError("pexpire", err)
+	}
+	return ok, nil
+}
+
+// StartPoolMetrics sets the gauges redis_pool_total_conns,
+// redis_pool_idle_conns and redis_pool_timeouts, the number of times a caller
+// timed out waiting for a connection, from the pool's stats every interval, or
+// every 15 seconds if interval is not positive, until ctx is cancelled.
+func (rc *redisClient) StartPoolMetrics(ctx context.Context, stats StatsClient, interval time.Duration) {
+	total := stats.Gauge("redis_pool_total_conns")
+	idle := stats.Gauge("redis_pool_idle_conns")
+	timeouts := stats.Gauge("redis_pool_timeouts")
+	report := func() {
+		stat := rc.client.PoolStats()
+		total.Set(float64(stat.TotalConns))
+		idle.Set(float64(stat.IdleConns))
+		timeouts.Set(float64(stat.Timeouts))
+	}
+
+	go func() {
+		ticker := time.NewTicker(poolMetricsInterval(interval))
+		defer ticker.Stop()
+		report()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report()
+			}
+		}
+	}()
+}
+
+// ScanKeys returns all keys matching pattern. It uses SCAN rather than KEYS so
+// it does not block the server, and satisfies cache.Cache.
 func (rc *redisClient) ScanKeys(ctx context.Context, pattern string) ([]string, error) {
+	return rc.ScanKeysBatch(ctx, pattern, 100)
+}
+
+// ScanKeysBatch is like ScanKeys but asks the server for about batch keys per
+// SCAN call. It stops with the context's error if ctx is cancelled mid-scan.
+func (rc *redisClient) ScanKeysBatch(ctx context.Context, pattern string, batch int64) ([]string, error) {
+	cluster, ok := rc.client.(*redis.ClusterClient)
+	if !ok {
+		return scanKeys(ctx, rc.client, pattern, batch)
+	}
+
+	// SCAN only covers the node it is sent to, so scan every master.
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		nodeKeys, err := scanKeys(ctx, node, pattern, batch)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		keys = append(keys, nodeKeys...)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func scanKeys(ctx context.Context, client redis.Cmdable, pattern string, batch int64) ([]string, error) {
 	var (
 		cursor uint64
 		keys   []string
 	)
 	for {
-		batch, newCursor, err := rc.client.Scan(ctx, cursor, pattern, 100).Result()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, newCursor, err := client.Scan(ctx, cursor, pattern, batch).Result()
 		if err != nil {
-			return nil, fmt.Errorf("redis scan error: %w", err)
+			return nil, redisError("scan", err)
 		}
-		keys = append(keys, batch...)
+		keys = append(keys, page...)
 		cursor = newCursor
 		if cursor == 0 {
 			break
@@ -151,7 +620,7 @@ func (rc *redisClient) ScanKeys(ctx context.Context, pattern string) ([]string,
 func (rc *redisClient) Incr(ctx context.Context, key string) error {
 	res := rc.client.Incr(ctx, key)
 	if res.Err() != nil {
-		return res.Err()
+		return redisError("incr", res.Err())
 	}
 	return nil
 }
@@ -160,7 +629,7 @@ func (rc *redisClient) Incr(ctx context.Context, key string) error {
 func (rc *redisClient) IncrBy(ctx context.Context, key string, amount int64) (int64, error) {
 	res := rc.client.IncrBy(ctx, key, amount)
 	if res.Err() != nil {
-		return 0, res.Err()
+		return 0, redisError("incrby", res.Err())
 	}
 	return res.Val(), nil
 }
@@ -168,7 +637,7 @@ func (rc *redisClient) IncrBy(ctx context.Context, key string, amount int64) (in
 func (rc *redisClient) Decr(ctx context.Context, key string) error {
 	res := rc.client.Decr(ctx, key)
 	if res.Err() != nil {
-		return res.Err()
+		return redisError("decr", res.Err())
 	}
 	return nil
 }
@@ -176,11 +645,97 @@ func (rc *redisClient) Decr(ctx context.Context, key string) error {
 func (rc *redisClient) DecrBy(ctx context.Context, key string, amount int64) (int64, error) {
 	res := rc.client.DecrBy(ctx, key, amount)
 	if res.Err() != nil {
-		return 0, res.Err()
+		return 0, redisError("decrby", res.Err())
 	}
 	return res.Val(), nil
 }
 
+// Publish JSON-encodes msg and publishes it to channel.
+func (rc *redisClient) Publish(ctx context.Context, channel string, msg interface{}) error {
+	jsonString, err := marshalValue(msg)
+	if err != nil {
+		return err
+	}
+	if err := rc.client.Publish(ctx, channel, jsonString).Err(); err != nil {
+		return redisError("publish", err)
+	}
+	return nil
+}
+
+// Subscribe subscribes to the given channels and streams received messages
+// until ctx is cancelled, at which point the subscription is closed along with
+// the returned channel. Dropped connections are re-established and the
+// channels resubscribed automatically; messages published while disconnected
+// are lost, as Redis pub/sub does not buffer them.
+func (rc *redisClient) Subscribe(ctx context.Context, channels ...string) (<-chan Message, error) {
+	pubsub := rc.client.Subscribe(ctx, channels...)
+	// Wait for the subscription to be confirmed before returning.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, redisError("subscribe", err)
+	}
+
+	messages := make(chan Message)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+		received := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-received:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- Message{Channel: msg.Channel, Payload: msg.Payload}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+// unlockScript deletes the lock key only if it still holds the caller's token.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Lock tries to acquire a distributed lock on key that expires after ttl.
+// acquired is false, with a nil error, when another owner holds the lock.
+// The returned unlock releases the lock only if it is still owned by this
+// caller, so an expired lock taken over by someone else is left alone.
+func (rc *redisClient) Lock(ctx context.Context, key string, ttl time.Duration) (unlock func() error, acquired bool, err error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, false, fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	ok, err := rc.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, false, redisError("lock", err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	unlock = func() error {
+		if err := unlockScript.Run(context.WithoutCancel(ctx), rc.client, []string{key}, token).Err(); err != nil {
+			return redisError("unlock", err)
+		}
+		return nil
+	}
+	return unlock, true, nil
+}
+
 // ProtoClient wraps RedisClient to handle protobuf operations
 type ProtoClient struct {
 	client RedisClient
@@ -196,9 +751,9 @@ func (pc *ProtoClient) GetProto(ctx context.Context, key string, msg proto.Messa
 	result := pc.client.Get(ctx, key)
 	if err := result.Err(); err != nil {
 		if err == redis.Nil {
-			return fmt.Errorf("key %s not found", key)
+			return fmt.Errorf("key %s %w", key, ErrNotFound)
 		}
-		return fmt.Errorf("redis get error: %w", err)
+		return redisError("get", err)
 	}
 
 	data, err := result.Bytes()
@@ -213,6 +768,35 @@ func (pc *ProtoClient) GetProto(ctx context.Context, key string, msg proto.Messa
 	return nil
 }
 
+// MGetProto retrieves several protobuf messages in one round trip. Each found
+// value is unmarshaled into a fresh message from newMsg. The returned slices
+// are parallel to keys: missing keys have a nil message and a false found flag.
+// On a Redis Cluster all keys must hash to the same slot, e.g. by sharing a
+// {hash tag}.
+func (pc *ProtoClient) MGetProto(ctx context.Context, keys []string, newMsg func() proto.Message) ([]proto.Message, []bool, error) {
+	values, err := pc.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, nil, redisError("mget", err)
+	}
+
+	msgs := make([]proto.Message, len(keys))
+	found := make([]bool, len(keys))
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		msg := newMsg()
+		if err := proto.Unmarshal([]byte(data), msg); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal proto for key %s: %w", keys[i], err)
+		}
+		msgs[i] = msg
+		found[i] = true
+	}
+
+	return msgs, found, nil
+}
+
 // SetProto marshals and stores a protobuf message
 func (pc *ProtoClient) SetProto(ctx context.Context, key string, msg proto.Message, expiration time.Duration) error {
 	data, err := proto.Marshal(msg)
@@ -221,7 +805,7 @@ func (pc *ProtoClient) SetProto(ctx context.Context, key string, msg proto.Messa
 	}
 
 	if err := pc.client.Set(ctx, key, data, expiration).Err(); err != nil {
-		return fmt.Errorf("redis set error: %w", err)
+		return redisError("set", err)
 	}
 
 	return nil
@@ -235,7 +819,21 @@ func (pc *ProtoClient) LPushProto(ctx context.Context, key string, msg proto.Mes
 	}
 
 	if err := pc.client.LPush(ctx, key, data).Err(); err != nil {
-		return fmt.Errorf("redis lpush error: %w", err)
+		return redisError("lpush", err)
+	}
+
+	return nil
+}
+
+// PublishProto marshals and publishes a protobuf message to a channel
+func (pc *ProtoClient) PublishProto(ctx context.Context, channel string, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal proto: %w", err)
+	}
+
+	if err := pc.client.Publish(ctx, channel, data).Err(); err != nil {
+		return redisError("publish", err)
 	}
 
 	return nil
@@ -248,7 +846,7 @@ func (pc *ProtoClient) BLPopProto(ctx context.Context, timeout time.Duration, ms
 		if err == redis.Nil {
 			return "", fmt.Errorf("no data available within timeout")
 		}
-		return "", fmt.Errorf("redis blpop error: %w", err)
+		return "", redisError("blpop", err)
 	}
 
 	// BLPop returns [key, value]
@@ -271,7 +869,7 @@ func (pc *ProtoClient) BLPopProto(ctx context.Context, timeout time.Duration, ms
 // DeleteKeys deletes one or more keys
 func (pc *ProtoClient) DeleteKeys(ctx context.Context, keys ...string) error {
 	if err := pc.client.Del(ctx, keys...).Err(); err != nil {
-		return fmt.Errorf("redis del error: %w", err)
+		return redisError("del", err)
 	}
 	return nil
 }
@@ -1,21 +1,245 @@
 package clients
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 type S3Client interface {
 	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
 	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
 	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
   ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+
+	// Multipart uploads, see MultipartUpload
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
 }
 
 type PresignClient interface {
 	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+	PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
 }
 
 var _ S3Client = (*s3.Client)(nil)
+var _ PresignClient = (*s3.PresignClient)(nil)
+
+// NewS3Client creates an S3 client for region using a static access key.
+func NewS3Client(region, accessKeyID, secretAccessKey string) *s3.Client {
+	return newS3Client(region, aws.Credentials{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		Source:          "NewS3Client",
+	})
+}
+
+// NewS3ClientFromENV creates an S3 client using the standard AWS credential
+// chain: environment variables such as AWS_REGION and AWS_ACCESS_KEY_ID,
+// shared config and credentials files, including AWS_PROFILE and SSO, web
+// identity tokens as used by IRSA, and ECS or EC2 instance roles.
+func NewS3ClientFromENV(ctx context.Context) (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("no aws region configured, set AWS_REGION")
+	}
+	return s3.NewFromConfig(cfg), nil
+}
+
+func newS3Client(region string, creds aws.Credentials) *s3.Client {
+	return s3.New(s3.Options{
+		Region: region,
+		Credentials: aws.NewCredentialsCache(aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return creds, nil
+		})),
+	})
+}
+
+// S3Presigner generates time-limited URLs that let clients such as browsers
+// read or write objects directly.
+type S3Presigner struct {
+	client PresignClient
+}
+
+// NewS3Presigner creates a presigner using the credentials of client.
+func NewS3Presigner(client *s3.Client) *S3Presigner {
+	return &S3Presigner{client: s3.NewPresignClient(client)}
+}
+
+// PresignGetObject returns a URL that downloads bucket/key until expires has passed.
+func (p *S3Presigner) PresignGetObject(ctx context.Context, bucket, key string, expires time.Duration) (string, error) {
+	req, err := p.client.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign get: %w", err)
+	}
+	return req.URL, nil
+}
+
+// PresignPutObject returns a URL that uploads to bucket/key with a PUT until
+// expires has passed, and the headers that were signed with it. The upload
+// must send exactly those headers or S3 rejects the signature.
+func (p *S3Presigner) PresignPutObject(ctx context.Context, bucket, key string, expires time.Duration) (string, http.Header, error) {
+	req, err := p.client.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to presign put: %w", err)
+	}
+	return req.URL, req.SignedHeader, nil
+}
+
+// MinPartSize is the smallest part S3 accepts in a multipart upload, other
+// than the last part.
+const MinPartSize = 5 << 20
+
+// MaxParts is the most parts S3 accepts in a multipart upload.
+const MaxParts = 10000
+
+// MultipartUpload uploads everything read from r to bucket/key in parts of
+// partSize bytes, uploading up to concurrency parts at once. A partSize below
+// MinPartSize is raised to it. An upload is limited to MaxParts parts, so r
+// must hold at most MaxParts*partSize bytes; reading more fails the upload. If
+// any part fails, the upload is aborted so S3 does not keep the parts already
+// stored.
+func MultipartUpload(ctx context.Context, client S3Client, bucket, key string, r io.Reader, partSize int64, concurrency int) (*s3.CompleteMultipartUploadOutput, error) {
+	if partSize < MinPartSize {
+		partSize = MinPartSize
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	created, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+
+	parts, err := uploadParts(ctx, client, bucket, key, created.UploadId, r, partSize, concurrency)
+	if err != nil {
+		_, abortErr := client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(key),
+			UploadId: created.UploadId,
+		})
+		if abortErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to abort multipart upload: %w", abortErr))
+		}
+		return nil, err
+	}
+
+	completed, err := client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		UploadId:        created.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return completed, nil
+}
+
+// uploadParts reads r in chunks of partSize and uploads them concurrently,
+// returning the completed parts in order.
+func uploadParts(ctx context.Context, client S3Client, bucket, key string, uploadID *string, r io.Reader, partSize int64, concurrency int) ([]types.CompletedPart, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		parts    []types.CompletedPart
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+		mu.Unlock()
+	}
+
+	sem := make(chan struct{}, concurrency)
+	for partNumber := int32(1); ctx.Err() == nil; partNumber++ {
+		buf := make([]byte, partSize)
+		n, err := io.ReadFull(r, buf)
+		if err == io.EOF {
+			if partNumber > 1 {
+				break
+			}
+			// An empty object is uploaded as a single empty part.
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			fail(fmt.Errorf("failed to read part %d: %w", partNumber, err))
+			break
+		}
+		if partNumber > MaxParts {
+			fail(fmt.Errorf("upload exceeds %d parts of %d bytes, use a larger part size", MaxParts, partSize))
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(partNumber int32, body []byte) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			out, err := client.UploadPart(ctx, &s3.UploadPartInput{
+				Bucket:     aws.String(bucket),
+				Key:        aws.String(key),
+				UploadId:   uploadID,
+				PartNumber: aws.Int32(partNumber),
+				Body:       bytes.NewReader(body),
+			})
+			if err != nil {
+				fail(fmt.Errorf("failed to upload part %d: %w", partNumber, err))
+				return
+			}
+			mu.Lock()
+			parts = append(parts, types.CompletedPart{ETag: out.ETag, PartNumber: aws.Int32(partNumber)})
+			mu.Unlock()
+		}(partNumber, buf[:n])
+
+		if err == io.ErrUnexpectedEOF {
+			break
+		}
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		return *parts[i].PartNumber < *parts[j].PartNumber
+	})
+	return parts, nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
@@ -16,8 +17,33 @@ type StatsCounter interface {
 	Inc()
 }
 
+type StatsCounterVec interface {
+	With(labelValues ...string) StatsCounter
+}
+
+type StatsGauge interface {
+	Set(float64)
+	Inc()
+	Dec()
+}
+
+type StatsHistogram interface {
+	Observe(float64)
+}
+
+type StatsHistogramVec interface {
+	With(labelValues ...string) StatsHistogram
+}
+
 type StatsClient interface {
 	Counter(name string) StatsCounter
+	CounterWithHelp(name, help string) StatsCounter
+	CounterVec(name string, labels ...string) StatsCounterVec
+	Gauge(name string) StatsGauge
+	Histogram(name string, buckets []float64) StatsHistogram
+	HistogramVec(name string, buckets []float64, labels ...string) StatsHistogramVec
+	RegisterCounter(name string)
+	RegisterCounterVec(name string, labels []string, values ...[]string)
 	Scope(scopes ...string) StatsClient
 }
 
@@ -31,60 +57,284 @@ func StartPromListener(port int) {
 	}()
 }
 
-var (
-	registeredCache = make(map[string]prometheus.Counter)
-	cacheMutex      sync.Mutex
-)
+// StartPromListenerFor serves the metrics of registry, e.g. one passed to
+// NewStatsV2ClientWithRegistry, on /metrics at port.
+func StartPromListenerFor(port int, registry *prometheus.Registry) {
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+		if err := http.ListenAndServe(fmt.Sprintf(":%d", port), mux); err != nil {
+			log.Fatalf("Failed to start Prometheus listener: %v", err)
+		}
+	}()
+}
 
-func fetchCounter(name string) prometheus.Counter {
-	cacheMutex.Lock()
-	defer cacheMutex.Unlock()
+// metricCache holds the metrics registered with a registerer, so asking for a
+// metric twice returns it instead of failing to register a duplicate.
+type metricCache struct {
+	registerer prometheus.Registerer
 
-	if counter, ok := registeredCache[name]; ok {
-		return counter
-	}
+	mu         sync.Mutex
+	counters   map[string]prometheus.Counter
+	gauges     map[string]prometheus.Gauge
+	histograms map[string]prometheus.Histogram
+	vecs       map[string]*prometheus.CounterVec
+	histVecs   map[string]*prometheus.HistogramVec
+}
 
-	return nil
+func newMetricCache(registerer prometheus.Registerer) *metricCache {
+	return &metricCache{
+		registerer: registerer,
+		counters:   make(map[string]prometheus.Counter),
+		gauges:     make(map[string]prometheus.Gauge),
+		histograms: make(map[string]prometheus.Histogram),
+		vecs:       make(map[string]*prometheus.CounterVec),
+		histVecs:   make(map[string]*prometheus.HistogramVec),
+	}
 }
 
+var (
+	defaultMetrics = newMetricCache(prometheus.DefaultRegisterer)
+
+	// registryMetrics holds the metric cache of each custom registry, so
+	// clients sharing a registry share its metrics.
+	registryMetrics = make(map[*prometheus.Registry]*metricCache)
+	registryMutex   sync.Mutex
+)
+
 func scopeToName(scopes []string) string {
 	return strings.Join(scopes, ":")
 }
 
 type StatsV2Client struct {
-	scopes []string
+	scopes  []string
+	metrics *metricCache
 }
 
+// NewStatsV2Client creates a client registering its metrics with the global
+// Prometheus registry, served by StartPromListener.
 func NewStatsV2Client(scopes ...string) *StatsV2Client {
 	return &StatsV2Client{
-		scopes: scopes,
+		scopes:  scopes,
+		metrics: defaultMetrics,
 	}
 }
 
+// NewStatsV2ClientWithRegistry creates a client registering its metrics with
+// registry instead of the global one, e.g. to give each test its own metrics.
+// Serve them with StartPromListenerFor.
+func NewStatsV2ClientWithRegistry(registry *prometheus.Registry, scopes ...string) *StatsV2Client {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	metrics, ok := registryMetrics[registry]
+	if !ok {
+		metrics = newMetricCache(registry)
+		registryMetrics[registry] = metrics
+	}
+	return &StatsV2Client{
+		scopes:  scopes,
+		metrics: metrics,
+	}
+}
+
+// Counter returns the counter with the given name, using the scoped name as
+// its help text.
 func (s *StatsV2Client) Counter(name string) StatsCounter {
+	return s.CounterWithHelp(name, "")
+}
+
+// CounterWithHelp returns the counter with the given name, creating it with
+// help as the description shown by Prometheus. The help of an existing
+// counter is not changed.
+func (s *StatsV2Client) CounterWithHelp(name, help string) StatsCounter {
 	newName := scopeToName(append(s.scopes, name))
-	if counter := fetchCounter(newName); counter != nil {
+	if help == "" {
+		help = newName
+	}
+
+	s.metrics.mu.Lock()
+	defer s.metrics.mu.Unlock()
+
+	if counter, ok := s.metrics.counters[newName]; ok {
 		return counter
 	}
 
 	counter := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: newName,
-			Help: "Some name",
+			Help: help,
 		},
 	)
 
-	prometheus.MustRegister(counter)
-
-	cacheMutex.Lock()
-	registeredCache[newName] = counter
-	cacheMutex.Unlock()
+	s.metrics.registerer.MustRegister(counter)
+	s.metrics.counters[newName] = counter
 
 	return counter
 }
 
+// CounterVec returns a counter partitioned by labels, e.g.
+//
+//	stats.CounterVec("http_requests_total", "status").With("500").Inc()
+//
+// The label values passed to With must match labels in number and order.
+func (s *StatsV2Client) CounterVec(name string, labels ...string) StatsCounterVec {
+	newName := scopeToName(append(s.scopes, name))
+
+	s.metrics.mu.Lock()
+	defer s.metrics.mu.Unlock()
+
+	if vec, ok := s.metrics.vecs[newName]; ok {
+		return &counterVec{vec: vec}
+	}
+
+	vec := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: newName,
+			Help: newName,
+		},
+		labels,
+	)
+
+	s.metrics.registerer.MustRegister(vec)
+	s.metrics.vecs[newName] = vec
+
+	return &counterVec{vec: vec}
+}
+
+type counterVec struct {
+	vec *prometheus.CounterVec
+}
+
+func (c *counterVec) With(labelValues ...string) StatsCounter {
+	return c.vec.WithLabelValues(labelValues...)
+}
+
+func (s *StatsV2Client) Gauge(name string) StatsGauge {
+	newName := scopeToName(append(s.scopes, name))
+
+	s.metrics.mu.Lock()
+	defer s.metrics.mu.Unlock()
+
+	if gauge, ok := s.metrics.gauges[newName]; ok {
+		return gauge
+	}
+
+	gauge := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: newName,
+			Help: newName,
+		},
+	)
+
+	s.metrics.registerer.MustRegister(gauge)
+	s.metrics.gauges[newName] = gauge
+
+	return gauge
+}
+
+// Histogram returns the histogram with the given name, creating it with
+// buckets on first use. Nil buckets use prometheus.DefBuckets, which suit
+// latencies measured in seconds. Later calls return the existing histogram
+// regardless of buckets.
+func (s *StatsV2Client) Histogram(name string, buckets []float64) StatsHistogram {
+	newName := scopeToName(append(s.scopes, name))
+
+	s.metrics.mu.Lock()
+	defer s.metrics.mu.Unlock()
+
+	if histogram, ok := s.metrics.histograms[newName]; ok {
+		return histogram
+	}
+
+	histogram := prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    newName,
+			Help:    newName,
+			Buckets: buckets,
+		},
+	)
+
+	s.metrics.registerer.MustRegister(histogram)
+	s.metrics.histograms[newName] = histogram
+
+	return histogram
+}
+
+// HistogramVec returns the histogram with the given name partitioned by
+// labels, creating it with buckets on first use. Nil buckets use
+// prometheus.DefBuckets. The label values passed to With must match labels in
+// number and order.
+func (s *StatsV2Client) HistogramVec(name string, buckets []float64, labels ...string) StatsHistogramVec {
+	newName := scopeToName(append(s.scopes, name))
+
+	s.metrics.mu.Lock()
+	defer s.metrics.mu.Unlock()
+
+	if vec, ok := s.metrics.histVecs[newName]; ok {
+		return &histogramVec{vec: vec}
+	}
+
+	vec := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    newName,
+			Help:    newName,
+			Buckets: buckets,
+		},
+		labels,
+	)
+
+	s.metrics.registerer.MustRegister(vec)
+	s.metrics.histVecs[newName] = vec
+
+	return &histogramVec{vec: vec}
+}
+
+type histogramVec struct {
+	vec *prometheus.HistogramVec
+}
+
+func (h *histogramVec) With(labelValues ...string) StatsHistogram {
+	return h.vec.WithLabelValues(labelValues...)
+}
+
+// RegisterCounter creates the counter with a value of zero so it is exported
+// before its first increment. Call it at startup for counters that alerts
+// depend on, so "no errors yet" reads as 0 rather than as missing data.
+func (s *StatsV2Client) RegisterCounter(name string) {
+	s.Counter(name)
+}
+
+// RegisterCounterVec creates the counter vec and, for each given set of label
+// values, its counter with a value of zero, e.g.
+//
+//	stats.RegisterCounterVec("http_requests_total", []string{"status"}, []string{"200"}, []string{"500"})
+//
+// Like RegisterCounter, it makes alerts on those label combinations see 0
+// before the first increment rather than missing data.
+func (s *StatsV2Client) RegisterCounterVec(name string, labels []string, values ...[]string) {
+	vec := s.CounterVec(name, labels...)
+	for _, labelValues := range values {
+		vec.With(labelValues...)
+	}
+}
+
 func (s *StatsV2Client) Scope(scopes ...string) StatsClient {
 	return &StatsV2Client{
-		scopes: append(s.scopes, scopes...),
+		scopes:  append(s.scopes, scopes...),
+		metrics: s.metrics,
+	}
+}
+
+// defaultPoolMetricsInterval is how often StartPoolMetrics reports when no
+// positive interval is given.
+const defaultPoolMetricsInterval = 15 * time.Second
+
+// poolMetricsInterval returns interval, or the default if it is not positive.
+func poolMetricsInterval(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return defaultPoolMetricsInterval
 	}
+	return interval
 }
@@ -0,0 +1,119 @@
+package clients
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// NoopLogger is a Logger that discards everything
+type NoopLogger struct{}
+
+func (NoopLogger) Named(map[string]string) Logger     { return NoopLogger{} }
+func (NoopLogger) Info(string, ...interface{})        {}
+func (NoopLogger) Warn(string, ...interface{})        {}
+func (NoopLogger) Error(string, ...interface{})       {}
+func (NoopLogger) Debug(string, ...interface{})       {}
+func (NoopLogger) Fatal(string, ...interface{})       {}
+func (NoopLogger) WithContext(context.Context) Logger { return NoopLogger{} }
+
+// LogEntry is a message recorded by a TestLogger
+type LogEntry struct {
+	Level         zapcore.Level
+	Msg           string
+	Scopes        map[string]string
+	KeysAndValues []interface{}
+}
+
+// TestLogger is a Logger that records messages so tests can assert on them.
+// Fatal is recorded like any other level and does not exit
+type TestLogger struct {
+	scopes  map[string]string
+	entries *logEntries
+}
+
+// logEntries is shared by a TestLogger and the loggers derived from it
+type logEntries struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+// NewTestLogger initializes an empty TestLogger
+func NewTestLogger() *TestLogger {
+	return &TestLogger{entries: &logEntries{}}
+}
+
+// Entries returns the messages recorded by the logger and any logger derived
+// from it with Named or WithContext, in the order they were logged
+func (l *TestLogger) Entries() []LogEntry {
+	l.entries.mu.Lock()
+	defer l.entries.mu.Unlock()
+	return append([]LogEntry(nil), l.entries.entries...)
+}
+
+// Reset discards the recorded messages
+func (l *TestLogger) Reset() {
+	l.entries.mu.Lock()
+	l.entries.entries = nil
+	l.entries.mu.Unlock()
+}
+
+func (l *TestLogger) Named(scopes map[string]string) Logger {
+	newScopes := make(map[string]string)
+	for k, v := range l.scopes {
+		newScopes[k] = v
+	}
+	for k, v := range scopes {
+		newScopes[k] = v
+	}
+	return &TestLogger{
+		scopes:  newScopes,
+		entries: l.entries,
+	}
+}
+
+func (l *TestLogger) WithContext(ctx context.Context) Logger {
+	scopes := contextScopes(ctx, DefaultLogContextKeys)
+	if len(scopes) == 0 {
+		return l
+	}
+	return l.Named(scopes)
+}
+
+func (l *TestLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record(zapcore.InfoLevel, msg, keysAndValues)
+}
+
+func (l *TestLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.record(zapcore.WarnLevel, msg, keysAndValues)
+}
+
+func (l *TestLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.record(zapcore.ErrorLevel, msg, keysAndValues)
+}
+
+func (l *TestLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.record(zapcore.DebugLevel, msg, keysAndValues)
+}
+
+func (l *TestLogger) Fatal(msg string, keysAndValues ...interface{}) {
+	l.record(zapcore.FatalLevel, msg, keysAndValues)
+}
+
+func (l *TestLogger) record(level zapcore.Level, msg string, keysAndValues []interface{}) {
+	l.entries.mu.Lock()
+	l.entries.entries = append(l.entries.entries, LogEntry{
+		Level:         level,
+		Msg:           msg,
+		Scopes:        l.scopes,
+		KeysAndValues: keysAndValues,
+	})
+	l.entries.mu.Unlock()
+}
+
+var (
+	_ Logger = NoopLogger{}
+	_ Logger = (*TestLogger)(nil)
+	_ Logger = (*LoggingClient)(nil)
+)
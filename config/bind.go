@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load returns a T populated from the environment. See Bind.
+func Load[T any]() (*T, error) {
+	var target T
+	if err := Bind(&target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// Bind populates the struct pointed to by target from the environment using
+// its field tags, e.g.
+//
+//	type Config struct {
+//		DatabaseURL string        `env:"DATABASE_URL" required:"true"`
+//		Port        int           `env:"PORT" default:"8080"`
+//		Debug       bool          `env:"DEBUG"`
+//		Timeout     time.Duration `env:"TIMEOUT" default:"30s"`
+//	}
+//
+// String, int, uint, float, bool and time.Duration fields are supported, and
+// nested structs without an env tag are bound recursively. Every missing or
+// unparsable value is reported in the returned error, along with the fields
+// that fail their `validate` tags, see Validate. A *ValidationError among them
+// can be retrieved with errors.As.
+func Bind(target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config target must be a pointer to a struct, got %T", target)
+	}
+	errs := bindStruct(v.Elem())
+	// Validate even when binding failed, so one error lists every problem.
+	return errors.Join(append(errs, Validate(target))...)
+}
+
+func bindStruct(v reflect.Value) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("env")
+		if key == "" {
+			if field.Type.Kind() == reflect.Struct {
+				errs = append(errs, bindStruct(v.Field(i))...)
+			}
+			continue
+		}
+
+		val := GetValue(key)
+		if val == "" {
+			val = field.Tag.Get("default")
+		}
+		if val == "" {
+			if field.Tag.Get("required") == "true" {
+				errs = append(errs, fmt.Errorf("required config %s is not set", key))
+			}
+			continue
+		}
+
+		if err := setField(v.Field(i), val); err != nil {
+			errs = append(errs, fmt.Errorf("invalid config %s: %w", key, err))
+		}
+	}
+	return errs
+}
+
+// setField parses val into a field according to its type.
+func setField(field reflect.Value, val string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(val, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(val, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	_ "github.com/joho/godotenv/autoload"
@@ -15,3 +19,62 @@ func GetValue(key string) string {
 	val := os.Getenv(key)
 	return val
 }
+
+// MustGet returns the value of key, panicking if it is unset or empty. Use it
+// at startup so a service with missing config fails immediately.
+func MustGet(key string) string {
+	val := GetValue(key)
+	if val == "" {
+		panic(fmt.Sprintf("required config %s is not set", key))
+	}
+	return val
+}
+
+// Require checks that every key is set, returning an error that lists all
+// the missing ones.
+func Require(keys ...string) error {
+	var errs []error
+	for _, key := range keys {
+		if GetValue(key) == "" {
+			errs = append(errs, fmt.Errorf("required config %s is not set", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetString returns the value of key, or def if it is empty.
+func GetString(key, def string) string {
+	if val := GetValue(key); val != "" {
+		return val
+	}
+	return def
+}
+
+// GetInt parses the value of key as an int, returning def if it is empty or invalid.
+func GetInt(key string, def int) int {
+	val, err := strconv.Atoi(GetValue(key))
+	if err != nil {
+		return def
+	}
+	return val
+}
+
+// GetBool parses the value of key as a bool, e.g. "true", "1" or "false",
+// returning def if it is empty or invalid.
+func GetBool(key string, def bool) bool {
+	val, err := strconv.ParseBool(GetValue(key))
+	if err != nil {
+		return def
+	}
+	return val
+}
+
+// GetDuration parses the value of key as a duration, e.g. "1m30s", returning
+// def if it is empty or invalid.
+func GetDuration(key string, def time.Duration) time.Duration {
+	val, err := time.ParseDuration(GetValue(key))
+	if err != nil {
+		return def
+	}
+	return val
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a config field that failed validation.
+type FieldError struct {
+	// Field is the field's env var name, or its Go name if it has no env tag.
+	Field string
+	// Tag is the validation rule that failed, e.g. "required" or "min".
+	Tag string
+	// Param is the rule's parameter, e.g. "1" for min=1.
+	Param string
+}
+
+func (e FieldError) String() string {
+	if e.Param != "" {
+		return fmt.Sprintf("%s (%s=%s)", e.Field, e.Tag, e.Param)
+	}
+	return fmt.Sprintf("%s (%s)", e.Field, e.Tag)
+}
+
+// ValidationError lists every config field that failed validation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		fields[i] = f.String()
+	}
+	return "invalid config: " + strings.Join(fields, ", ")
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// Report fields by the env var they are loaded from.
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		if name := field.Tag.Get("env"); name != "" {
+			return name
+		}
+		return field.Name
+	})
+	return v
+}
+
+// Validate checks a config struct against its `validate` tags, e.g.
+//
+//	type Config struct {
+//		DatabaseURL string `env:"DATABASE_URL" validate:"required,url"`
+//		Mode        string `env:"MODE" validate:"oneof=dev prod"`
+//	}
+//
+// All failures are reported together in a *ValidationError, so every problem
+// can be fixed before the next deploy.
+func Validate(target interface{}) error {
+	err := validate.Struct(target)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	result := &ValidationError{}
+	for _, fe := range fieldErrs {
+		result.Fields = append(result.Fields, FieldError{
+			Field: fe.Field(),
+			Tag:   fe.Tag(),
+			Param: fe.Param(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// backgroundGroup tracks the work started by background nodes during a flow run.
+type backgroundGroup struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+type backgroundKey struct{}
+
+func withBackgroundGroup(ctx context.Context) (context.Context, *backgroundGroup) {
+	group := &backgroundGroup{}
+	return context.WithValue(ctx, backgroundKey{}, group), group
+}
+
+func (g *backgroundGroup) add(err error) {
+	g.mu.Lock()
+	g.errs = append(g.errs, err)
+	g.mu.Unlock()
+}
+
+// wait blocks until all background work has finished and returns its errors.
+func (g *backgroundGroup) wait() error {
+	g.wg.Wait()
+	g.mu.Lock()
+	errs := g.errs
+	g.errs = nil
+	g.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// backgroundNode starts its nodes concurrently without waiting for them.
+type backgroundNode struct {
+	baseNode
+	nodes []Node
+}
+
+// Run starts each node in its own goroutine and proceeds to the next node.
+func (n *backgroundNode) run(ctx context.Context, interceptors []Interceptor) error {
+	nodeCtx, done, err := enter(ctx, n, interceptors)
+	if err != nil {
+		return err
+	}
+
+	group, ok := ctx.Value(backgroundKey{}).(*backgroundGroup)
+	if !ok {
+		// Outside of a flow run there is nothing to drain the work later,
+		// so wait for it here.
+		var groupCtx context.Context
+		groupCtx, group = withBackgroundGroup(ctx)
+		defer group.wait()
+		ctx = groupCtx
+	}
+
+	group.wg.Add(len(n.nodes))
+	for _, node := range n.nodes {
+		go func(node Node) {
+			defer group.wg.Done()
+			ctx, cleanup := withCleanupScope(nodeCtx)
+			err := runRecovered(ctx, node, interceptors)
+			if err = withCleanup(err, cleanup); err != nil {
+				group.add(err)
+			}
+		}(node)
+	}
+	done(nil)
+
+	if n.next != nil {
+		return n.next.run(ctx, interceptors)
+	}
+	return nil
+}
+
+// barrierNode waits for all background work started before it.
+type barrierNode struct {
+	baseNode
+}
+
+// Run waits until all background work has drained, failing if any of it failed.
+func (n *barrierNode) run(ctx context.Context, interceptors []Interceptor) error {
+	_, done, err := enter(ctx, n, interceptors)
+	if err != nil {
+		return err
+	}
+	if group, ok := ctx.Value(backgroundKey{}).(*backgroundGroup); ok {
+		if waitErr := group.wait(); waitErr != nil {
+			err = fmt.Errorf("barrier %s: %w", n.name, waitErr)
+		}
+	}
+	done(err)
+	if err != nil {
+		return err
+	}
+	if n.next != nil {
+		return n.next.run(ctx, interceptors)
+	}
+	return nil
+}
+
+// InBackground creates a node that starts the provided nodes concurrently and
+// immediately continues with the rest of the flow. Use a Barrier to wait for
+// them; any work still running when the flow ends is waited for by Run, and
+// cancelled first if the flow failed.
+func InBackground(name string, nodes ...Node) Node {
+	var filteredNodes []Node
+	for _, node := range nodes {
+		if node != nil {
+			filteredNodes = append(filteredNodes, node)
+		}
+	}
+	return &backgroundNode{
+		baseNode: baseNode{
+			base: base{
+				name: name,
+			},
+		},
+		nodes: filteredNodes,
+	}
+}
+
+// Barrier creates a node that waits until all work started by preceding
+// InBackground nodes has finished. It fails with the joined errors of that
+// work, so no node after the barrier runs unless the whole stage succeeded.
+func Barrier(name string) Node {
+	return &barrierNode{
+		baseNode: baseNode{
+			base: base{
+				name: name,
+			},
+		},
+	}
+}
+
+// Barrier adds a barrier node to the flow. See the package-level Barrier.
+func (f *Flow) Barrier(name string) *Flow {
+	f.appendNode(Barrier(name))
+	return f
+}
@@ -0,0 +1,62 @@
+package flow
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time for a flow's retry backoff and node timeouts.
+// Tests can supply a fake clock to advance time without sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock used when none is set.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+type clockKey struct{}
+
+// WithClock sets the clock used by the flow's retry backoff and timeouts.
+func (f *Flow) WithClock(clock Clock) *Flow {
+	f.clock = clock
+	return f
+}
+
+// clockOrDefault returns the flow's clock, or the real clock if none is set.
+func (f *Flow) clockOrDefault() Clock {
+	if f.clock != nil {
+		return f.clock
+	}
+	return realClock{}
+}
+
+// clockFrom returns the clock of the running flow, or the real clock outside a run.
+func clockFrom(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return clock
+	}
+	return realClock{}
+}
+
+// withClockTimeout is context.WithTimeout measured on clock. Once the timeout
+// passes, context.Cause of the returned context is context.DeadlineExceeded.
+func withClockTimeout(ctx context.Context, clock Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := clock.(realClock); ok {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	expired := clock.After(timeout)
+	go func() {
+		select {
+		case <-expired:
+			cancel(context.DeadlineExceeded)
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() { cancel(context.Canceled) }
+}
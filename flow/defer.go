@@ -0,0 +1,68 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// cleanupScope collects the cleanup functions registered with Defer during a
+// flow run or a parallel branch.
+type cleanupScope struct {
+	mu  sync.Mutex
+	fns []func(context.Context)
+}
+
+type cleanupKey struct{}
+
+// Defer registers fn to run when the enclosing flow run or parallel branch
+// finishes, whether it succeeded, failed or was cancelled. Cleanups run in the
+// reverse order of registration and receive a context that is never cancelled,
+// so they can still release resources after the flow's context is done. A
+// panicking cleanup does not stop the others; it fails the flow with a
+// *PanicError. Defer has no effect when ctx does not belong to a running flow.
+func Defer(ctx context.Context, fn func(context.Context)) {
+	scope, ok := ctx.Value(cleanupKey{}).(*cleanupScope)
+	if !ok {
+		return
+	}
+	scope.mu.Lock()
+	scope.fns = append(scope.fns, fn)
+	scope.mu.Unlock()
+}
+
+// withCleanupScope returns a context with a new cleanup scope and a function
+// that runs the cleanups registered in it, returning the panics they raised.
+func withCleanupScope(ctx context.Context) (context.Context, func() error) {
+	scope := &cleanupScope{}
+	ctx = context.WithValue(ctx, cleanupKey{}, scope)
+	return ctx, func() error {
+		cleanupCtx := context.WithoutCancel(ctx)
+		scope.mu.Lock()
+		fns := scope.fns
+		scope.fns = nil
+		scope.mu.Unlock()
+		var errs []error
+		for i := len(fns) - 1; i >= 0; i-- {
+			if err := runCleanup(cleanupCtx, fns[i]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// runCleanup runs fn, converting a panic into a *PanicError.
+func runCleanup(ctx context.Context, fn func(context.Context)) (err error) {
+	defer recoverPanic("cleanup", &err)
+	fn(ctx)
+	return nil
+}
+
+// withCleanup joins the error returned by cleanup into err.
+func withCleanup(err error, cleanup func() error) error {
+	if cleanupErr := cleanup(); cleanupErr != nil {
+		return errors.Join(err, cleanupErr)
+	}
+	return err
+}
@@ -2,12 +2,17 @@ package flow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/micahke/mirage/clients"
 )
 
 // Node interface represents a node in the flow.
 type Node interface {
+	Name() string
 	run(context.Context, []Interceptor) error
 	setNext(Node)
 	getNext() Node
@@ -18,6 +23,11 @@ type base struct {
 	name string
 }
 
+// Name returns the node's name.
+func (b *base) Name() string {
+	return b.name
+}
+
 // baseNode embeds base and contains the next node in the flow.
 type baseNode struct {
 	base
@@ -37,17 +47,19 @@ func (n *baseNode) getNext() Node {
 // doNode represents an action node that executes a function.
 type doNode struct {
 	baseNode
-	fn func(context.Context) error
+	fn   func(context.Context) error
+	opts nodeOptions
 }
 
 // Run executes the node's function and proceeds to the next node.
 func (n *doNode) run(ctx context.Context, interceptors []Interceptor) error {
-	for _, i := range interceptors {
-		if err := i(ctx, n); err != nil {
-			return err
-		}
+	nodeCtx, done, err := enter(ctx, n, interceptors)
+	if err != nil {
+		return err
 	}
-	if err := n.fn(ctx); err != nil {
+	err = n.exec(nodeCtx)
+	done(err)
+	if err != nil {
 		return err
 	}
 	if n.next != nil {
@@ -65,15 +77,17 @@ type conditionalNode struct {
 
 // Run evaluates the condition and executes the true branch if the condition is true.
 func (n *conditionalNode) run(ctx context.Context, interceptors []Interceptor) error {
-	for _, i := range interceptors {
-		if err := i(ctx, n); err != nil {
-			return err
-		}
+	nodeCtx, done, err := enter(ctx, n, interceptors)
+	if err != nil {
+		return err
 	}
-	if n.condition(ctx) && n.trueBranch != nil {
-		if err := n.trueBranch.run(ctx, interceptors); err != nil {
-			return err
-		}
+	ok, err := n.test(nodeCtx)
+	if err == nil && ok && n.trueBranch != nil {
+		err = n.trueBranch.run(nodeCtx, interceptors)
+	}
+	done(err)
+	if err != nil {
+		return err
 	}
 	// Proceed to the next node regardless of the condition result
 	if n.next != nil {
@@ -82,6 +96,20 @@ func (n *conditionalNode) run(ctx context.Context, interceptors []Interceptor) e
 	return nil
 }
 
+// test evaluates the condition, returning a panic in it as a *PanicError.
+func (n *conditionalNode) test(ctx context.Context) (ok bool, err error) {
+	defer recoverPanic(n.name, &err)
+	return n.condition(ctx), nil
+}
+
+// runRecovered runs a node, returning a panic that escaped it as a
+// *PanicError instead of crashing the program. It guards the goroutines a
+// flow starts, where an unrecovered panic cannot be caught by the caller.
+func runRecovered(ctx context.Context, node Node, interceptors []Interceptor) (err error) {
+	defer recoverPanic(node.Name(), &err)
+	return node.run(ctx, interceptors)
+}
+
 // sequenceNode represents a sequence of nodes to be executed in order.
 type sequenceNode struct {
 	baseNode
@@ -113,9 +141,15 @@ type Flow struct {
 	tail             Node
 	flowInterceptors []Interceptor
 	nodeInterceptors []Interceptor
+	logger           clients.Logger
+	stats            *fanOutStats
+	retry            *RetryOptions
+	clock            Clock
 }
 
 // Ensure Flow implements Node by adding run, setNext, and getNext methods.
+// A flow nested in another node, e.g. InParallel, runs its nodes with the
+// enclosing flow's node interceptors followed by its own.
 func (f *Flow) run(ctx context.Context, interceptors []Interceptor) error {
 	if f.head == nil {
 		return nil
@@ -127,7 +161,21 @@ func (f *Flow) run(ctx context.Context, interceptors []Interceptor) error {
 		}
 	}
 	// Start execution from the head node
-	return f.head.run(ctx, f.nodeInterceptors)
+	return f.head.run(ctx, mergeInterceptors(interceptors, f.nodeInterceptors))
+}
+
+// mergeInterceptors returns the interceptors of an enclosing flow followed by
+// those of a nested one, without modifying either.
+func mergeInterceptors(outer, inner []Interceptor) []Interceptor {
+	if len(outer) == 0 {
+		return inner
+	}
+	if len(inner) == 0 {
+		return outer
+	}
+	merged := make([]Interceptor, 0, len(outer)+len(inner))
+	merged = append(merged, outer...)
+	return append(merged, inner...)
 }
 
 func (f *Flow) setNext(next Node) {
@@ -144,32 +192,48 @@ func (f *Flow) getNext() Node {
 	return nil
 }
 
+// DefaultLogger, when set, is attached by New to every flow it creates, so
+// flows log their start, end and node errors without wiring an interceptor.
+// Use NewWithLogger to choose a different logger, or none, for a single flow.
+var DefaultLogger clients.Logger
+
 // New creates a new flow with the given name.
 func New(name string) *Flow {
-	return &Flow{
+	return NewWithLogger(name, DefaultLogger)
+}
+
+// NewWithLogger creates a new flow that logs its start and end at info level
+// and node errors at error level. A nil logger disables logging.
+func NewWithLogger(name string, logger clients.Logger) *Flow {
+	f := &Flow{
 		base: base{name: name},
 	}
+	if logger != nil {
+		f.logger = logger.Named(map[string]string{"flow": name})
+		f.AddNodeInterceptor(errorLoggingInterceptor(f.logger))
+	}
+	return f
 }
 
-func (f *Flow) Name() string {
-  return f.name
+// Do adds a new action node to the flow. Options such as WithRetry or
+// WithTimeout can be combined to configure the node's behavior.
+func (f *Flow) Do(name string, fn func(context.Context) error, opts ...NodeOption) *Flow {
+	f.appendNode(Do(name, fn, opts...))
+	return f
 }
 
-// Do adds a new action node to the flow.
-func (f *Flow) Do(name string, fn func(context.Context) error) *Flow {
-	node := &doNode{
-		baseNode: baseNode{
-			base: base{
-				name: name,
-			},
-		},
-		fn: fn,
-	}
-	f.appendNode(node)
-	return f
+// DoWithFallback adds an action node that runs fallback when fn fails. A nil
+// error from fallback lets the flow continue, e.g. by serving a cached value
+// when a live fetch fails.
+func (f *Flow) DoWithFallback(name string, fn func(context.Context) error, fallback func(context.Context, error) error) *Flow {
+	return f.Do(name, fn, WithFallback(fallback))
 }
 
-// Then adds an existing node or flow to the current flow.
+// Then adds an existing node or flow to the current flow. The nodes of an
+// added flow become part of this flow and run with its interceptors; the added
+// flow's own interceptors are not kept. To keep them, nest the flow in a node
+// instead, e.g. Then(InSequence(name, sub)), so its nodes run with this flow's
+// node interceptors followed by its own.
 func (f *Flow) Then(node Node) *Flow {
 	switch n := node.(type) {
 	case *Flow:
@@ -210,6 +274,17 @@ func (f *Flow) If(name string, cond func(context.Context) bool, trueBranch Node)
 	return f
 }
 
+// IfEnabled adds the node to the flow only when enabled is true. Unlike If, the
+// decision is made while building the flow, so a disabled node is never part
+// of the graph and adds no runtime overhead. The name identifies the step
+// being toggled.
+func (f *Flow) IfEnabled(name string, enabled bool, node Node) *Flow {
+	if !enabled || node == nil {
+		return f
+	}
+	return f.Then(node)
+}
+
 // appendNode appends a node to the flow.
 func (f *Flow) appendNode(node Node) {
 	if f.head == nil {
@@ -240,30 +315,142 @@ func InSequence(name string, nodes ...Node) Node {
 }
 
 // Do creates a standalone action node.
-func Do(name string, fn func(context.Context) error) Node {
+func Do(name string, fn func(context.Context) error, opts ...NodeOption) Node {
 	return &doNode{
 		baseNode: baseNode{
 			base: base{
 				name: name,
 			},
 		},
-		fn: fn,
+		fn:   fn,
+		opts: newNodeOptions(opts),
 	}
 }
 
-// Run starts executing the flow from the head node.
+// Run starts executing the flow from the head node. Background work is
+// drained, saga steps are compensated if the flow failed, and cleanups
+// registered with Defer run before Run returns. A flow ended early by StopIf
+// returns nil. A panic in any node fails the flow with a *PanicError. With
+// RetryFlow, a failed run is repeated from the top.
 func (f *Flow) Run(ctx context.Context) error {
 	if f.head == nil {
 		return nil
 	}
+	retry := f.retry
+	if retry == nil || retry.MaxAttempts <= 1 {
+		return f.runOnce(ctx)
+	}
+
+	backoff := retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err := f.runOnce(ctx)
+		if err == nil || attempt >= retry.MaxAttempts {
+			return err
+		}
+		if retry.RetryIf != nil && !retry.RetryIf(err) {
+			return err
+		}
+		if f.logger != nil {
+			f.logger.Warn("retrying flow", "attempt", attempt, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-f.clockOrDefault().After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// runOnce runs the flow a single time.
+func (f *Flow) runOnce(ctx context.Context) (err error) {
+	ctx, cleanup := withCleanupScope(ctx)
+	defer func() { err = withCleanup(err, cleanup) }()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx, background := withBackgroundGroup(ctx)
+	ctx, sagas := withSagaLog(ctx)
+	ctx = context.WithValue(ctx, flowNameKey{}, f.name)
+	if f.stats != nil {
+		ctx = context.WithValue(ctx, statsKey{}, f.stats)
+	}
+	clock := f.clockOrDefault()
+	ctx = context.WithValue(ctx, clockKey{}, clock)
 	// Run flow interceptors with the flow itself
 	for _, i := range f.flowInterceptors {
 		if err := i(ctx, nil); err != nil {
 			return err
 		}
 	}
+	if f.logger != nil {
+		f.logger.Info("flow started")
+	}
+	start := clock.Now()
 	// Start execution with the head node
-	return f.head.run(ctx, f.nodeInterceptors)
+	err = runRecovered(ctx, f.head, f.nodeInterceptors)
+	if errors.Is(err, ErrFlowStopped) {
+		err = nil
+	}
+	if err != nil {
+		cancel()
+	}
+	if bgErr := background.wait(); err == nil {
+		err = bgErr
+	}
+	if err != nil {
+		if compErr := sagas.compensate(ctx); compErr != nil {
+			err = errors.Join(err, compErr)
+		}
+	}
+	if f.logger != nil {
+		if err != nil {
+			f.logger.Error("flow failed", "duration", clock.Now().Sub(start), "error", err)
+		} else {
+			f.logger.Info("flow finished", "duration", clock.Now().Sub(start))
+		}
+	}
+	return err
+}
+
+// ErrFlowTimeout is returned by RunWithTimeout when the flow did not finish in time.
+var ErrFlowTimeout = errors.New("flow timed out")
+
+// RunWithTimeout is like Run but cancels the flow's context once timeout has
+// passed, failing with an error wrapping ErrFlowTimeout and the error the flow
+// returned, if any. A flow that finishes after the timeout fails even if its
+// nodes ignored the cancellation and succeeded. Nodes stop only if they honor their context, and RunWithTimeout
+// waits for them, their cleanups and compensations like Run does. With
+// RetryFlow, the timeout covers all attempts.
+func (f *Flow) RunWithTimeout(ctx context.Context, timeout time.Duration) error {
+	runCtx, cancel := withClockTimeout(ctx, f.clockOrDefault(), timeout)
+	defer cancel()
+	err := f.Run(runCtx)
+	if ctx.Err() != nil || context.Cause(runCtx) != context.DeadlineExceeded {
+		return err
+	}
+	// The flow overran even if its nodes ignored the cancellation.
+	if err == nil {
+		return fmt.Errorf("%w after %s", ErrFlowTimeout, timeout)
+	}
+	return fmt.Errorf("%w after %s: %w", ErrFlowTimeout, timeout, err)
+}
+
+// defaultFlowRetryBackoff is the delay before the first re-run of a flow
+// configured with RetryFlow. It doubles after each attempt.
+const defaultFlowRetryBackoff = 100 * time.Millisecond
+
+// RetryFlow re-runs the whole flow, up to maxAttempts runs in total, when a
+// run fails with an error for which retryIf returns true, or with any error if
+// retryIf is nil. This suits read-compute-write cycles that must start over,
+// e.g. after an optimistic-lock conflict. Each run has its own Defer cleanups
+// and saga compensations, which complete before the next run starts.
+func (f *Flow) RetryFlow(maxAttempts int, retryIf func(error) bool) *Flow {
+	f.retry = &RetryOptions{
+		MaxAttempts: maxAttempts,
+		Backoff:     defaultFlowRetryBackoff,
+		RetryIf:     retryIf,
+	}
+	return f
 }
 
 // AddFlowInterceptor adds an interceptor that runs before the flow starts.
@@ -284,14 +471,20 @@ type parallelNode struct {
 	nodes []Node
 }
 
-// Run executes all nodes in parallel and waits for them to complete
+// Run executes all nodes in parallel and waits for them to complete.
+// When a branch fails, its siblings are cancelled, and the node returns the
+// first error once every branch has finished and run its Defer cleanups.
 func (n *parallelNode) run(ctx context.Context, interceptors []Interceptor) error {
-	for _, i := range interceptors {
-		if err := i(ctx, n); err != nil {
-			return err
-		}
+	nodeCtx, done, err := enter(ctx, n, interceptors)
+	if err != nil {
+		return err
 	}
 
+	recordFanOut(ctx, len(n.nodes))
+
+	branchCtx, cancel := context.WithCancel(nodeCtx)
+	defer cancel()
+
 	errChan := make(chan error, len(n.nodes))
 	var wg sync.WaitGroup
 	wg.Add(len(n.nodes))
@@ -300,22 +493,24 @@ func (n *parallelNode) run(ctx context.Context, interceptors []Interceptor) erro
 		go func(node Node) {
 			defer wg.Done()
 			if node != nil {
-				if err := node.run(ctx, interceptors); err != nil {
+				ctx, cleanup := withCleanupScope(branchCtx)
+				err := runRecovered(ctx, node, interceptors)
+				if err = withCleanup(err, cleanup); err != nil {
 					errChan <- err
+					cancel()
 				}
 			}
 		}(node)
 	}
 
-	go func() {
-		wg.Wait()
-		close(errChan)
-	}()
+	wg.Wait()
+	close(errChan)
 
-	for err := range errChan {
-		if err != nil {
-			return err
-		}
+	// The first error is the one that caused siblings to be cancelled.
+	err = <-errChan
+	done(err)
+	if err != nil {
+		return err
 	}
 
 	if n.next != nil {
@@ -0,0 +1,131 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects the nodes seen by its interceptors, in order.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) interceptor(label string) Interceptor {
+	return func(_ context.Context, n Node) error {
+		if n == nil {
+			return nil
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.calls = append(r.calls, label+":"+n.Name())
+		return nil
+	}
+}
+
+func noop(context.Context) error { return nil }
+
+func TestNestedFlowRunsParentInterceptorsFirst(t *testing.T) {
+	var r recorder
+
+	inner := New("inner").
+		Do("inner-1", noop).
+		Do("inner-2", noop).
+		AddNodeInterceptor(r.interceptor("inner"))
+
+	parent := New("parent").
+		Do("outer", noop).
+		Then(InSequence("nested", inner)).
+		AddNodeInterceptor(r.interceptor("parent"))
+
+	if err := parent.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := []string{
+		"parent:outer",
+		"parent:inner-1",
+		"inner:inner-1",
+		"parent:inner-2",
+		"inner:inner-2",
+	}
+	if !reflect.DeepEqual(r.calls, want) {
+		t.Errorf("interceptor calls = %v, want %v", r.calls, want)
+	}
+}
+
+func TestNestedFlowInParallelRunsParentInterceptors(t *testing.T) {
+	var r recorder
+
+	inner := New("inner").
+		Do("inner-1", noop).
+		AddNodeInterceptor(r.interceptor("inner"))
+
+	parent := New("parent").
+		Then(InParallel("fan-out", inner)).
+		AddNodeInterceptor(r.interceptor("parent"))
+
+	if err := parent.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := []string{"parent:fan-out", "parent:inner-1", "inner:inner-1"}
+	if !reflect.DeepEqual(r.calls, want) {
+		t.Errorf("interceptor calls = %v, want %v", r.calls, want)
+	}
+}
+
+func TestPanickingCleanupAndCompensationFailRun(t *testing.T) {
+	var cleanedUp, compensated bool
+
+	f := New("panics").
+		Do("defer", func(ctx context.Context) error {
+			Defer(ctx, func(context.Context) { cleanedUp = true })
+			Defer(ctx, func(context.Context) { panic("cleanup boom") })
+			return nil
+		}).
+		DoSaga("reserve", noop, func(context.Context) error { panic("compensate boom") }).
+		DoSaga("charge", noop, func(context.Context) error {
+			compensated = true
+			return nil
+		}).
+		Do("fail", func(context.Context) error { return errors.New("fail") })
+
+	err := f.Run(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var panics []*PanicError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var panicErr *PanicError
+		if errors.As(e, &panicErr) {
+			panics = append(panics, panicErr)
+		}
+	}
+	if len(panics) != 2 {
+		t.Errorf("got %d panics in %v, want 2", len(panics), err)
+	}
+	if !cleanedUp {
+		t.Error("cleanup registered before the panicking one did not run")
+	}
+	if !compensated {
+		t.Error("compensation before the panicking one did not run")
+	}
+}
+
+func TestRunWithTimeoutFailsWhenNodesIgnoreCancellation(t *testing.T) {
+	f := New("slow").Do("ignore-ctx", func(context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+
+	err := f.RunWithTimeout(context.Background(), 10*time.Millisecond)
+	if !errors.Is(err, ErrFlowTimeout) {
+		t.Errorf("RunWithTimeout = %v, want ErrFlowTimeout", err)
+	}
+}
@@ -0,0 +1,69 @@
+package flow
+
+import (
+	"context"
+	"sync"
+
+	"github.com/micahke/mirage/clients"
+)
+
+// nodeFrame holds the completion callbacks registered for a running node.
+type nodeFrame struct {
+	mu   sync.Mutex
+	done []func(error)
+}
+
+type frameKey struct{}
+
+// OnNodeDone registers fn to be called with the node's result once the node
+// being entered has finished. It is meant to be called from an Interceptor,
+// which only runs before a node, to observe the node's outcome. The result
+// is the node's own error, not that of the nodes after it.
+func OnNodeDone(ctx context.Context, fn func(err error)) {
+	frame, ok := ctx.Value(frameKey{}).(*nodeFrame)
+	if !ok {
+		return
+	}
+	frame.mu.Lock()
+	frame.done = append(frame.done, fn)
+	frame.mu.Unlock()
+}
+
+// enter runs the interceptors for a node and returns the context the node
+// should run with, and a function to call with the node's result when it
+// finishes.
+func enter(ctx context.Context, n Node, interceptors []Interceptor) (context.Context, func(error), error) {
+	frame := &nodeFrame{}
+	ctx = context.WithValue(ctx, frameKey{}, frame)
+	for _, i := range interceptors {
+		if err := i(ctx, n); err != nil {
+			return ctx, nil, err
+		}
+	}
+	return ctx, frame.finish, nil
+}
+
+func (f *nodeFrame) finish(err error) {
+	f.mu.Lock()
+	done := f.done
+	f.done = nil
+	f.mu.Unlock()
+	for _, fn := range done {
+		fn(err)
+	}
+}
+
+// errorLoggingInterceptor logs every node that fails at error level.
+func errorLoggingInterceptor(logger clients.Logger) Interceptor {
+	return func(ctx context.Context, n Node) error {
+		if n == nil {
+			return nil
+		}
+		OnNodeDone(ctx, func(err error) {
+			if err != nil {
+				logger.Error("node failed", "node", n.Name(), "error", err)
+			}
+		})
+		return nil
+	}
+}
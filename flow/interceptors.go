@@ -0,0 +1,88 @@
+package flow
+
+import (
+	"context"
+
+	"github.com/micahke/mirage/clients"
+)
+
+type flowNameKey struct{}
+
+// FlowName returns the name of the flow whose Run ctx belongs to, or "" if
+// ctx does not belong to a running flow.
+func FlowName(ctx context.Context) string {
+	name, _ := ctx.Value(flowNameKey{}).(string)
+	return name
+}
+
+// Kind returns the kind of a node: "do", "if", "stop", "sequence",
+// "parallel", "background", "barrier", "retry" or "flow".
+func Kind(node Node) string {
+	switch node.(type) {
+	case *doNode:
+		return "do"
+	case *conditionalNode:
+		return "if"
+	case *stopNode:
+		return "stop"
+	case *sequenceNode:
+		return "sequence"
+	case *parallelNode:
+		return "parallel"
+	case *backgroundNode:
+		return "background"
+	case *barrierNode:
+		return "barrier"
+	case *retryFlowNode:
+		return "retry"
+	case *Flow:
+		return "flow"
+	default:
+		return "unknown"
+	}
+}
+
+// MetricsInterceptor returns a node interceptor that reports every node's
+// outcome to the counter flow_node_total, labeled by node, kind and status
+// ("success" or "error"), and its duration to the histogram
+// flow_node_duration_seconds, labeled by node and kind. Add it with
+// AddNodeInterceptor.
+func MetricsInterceptor(stats clients.StatsClient) Interceptor {
+	total := stats.CounterVec("flow_node_total", "node", "kind", "status")
+	duration := stats.HistogramVec("flow_node_duration_seconds", nil, "node", "kind")
+	return func(ctx context.Context, n Node) error {
+		if n == nil {
+			return nil
+		}
+		clock := clockFrom(ctx)
+		start := clock.Now()
+		OnNodeDone(ctx, func(err error) {
+			name, kind := n.Name(), Kind(n)
+			status := "success"
+			if err != nil {
+				status = "error"
+			}
+			total.With(name, kind, status).Inc()
+			duration.With(name, kind).Observe(clock.Now().Sub(start).Seconds())
+		})
+		return nil
+	}
+}
+
+// LoggingInterceptor returns a node interceptor that logs every node entered
+// at debug level, with its name and kind. Lines are scoped with the name of
+// the running flow and carry the context values logged by WithContext. Add it
+// with AddNodeInterceptor.
+func LoggingInterceptor(logger clients.Logger) Interceptor {
+	return func(ctx context.Context, n Node) error {
+		if n == nil {
+			return nil
+		}
+		l := logger
+		if name := FlowName(ctx); name != "" {
+			l = l.Named(map[string]string{"flow": name})
+		}
+		l.WithContext(ctx).Debug("node started", "node", n.Name(), "kind", Kind(n))
+		return nil
+	}
+}
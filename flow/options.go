@@ -0,0 +1,172 @@
+package flow
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// NodeOption configures optional behavior of an action node.
+type NodeOption func(*nodeOptions)
+
+// nodeOptions holds the behaviors configured for an action node.
+type nodeOptions struct {
+	retry    *RetryOptions
+	timeout  time.Duration
+	tags     map[string]string
+	fallback func(context.Context, error) error
+}
+
+// RetryOptions configures how a failing node is retried.
+type RetryOptions struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles after each attempt.
+	Backoff time.Duration
+	// RetryIf reports whether an error should be retried. All errors are retried when nil.
+	RetryIf func(error) bool
+}
+
+// PanicError is returned by a node that panicked. Stack holds the stack trace
+// of the panicking goroutine.
+type PanicError struct {
+	Node  string
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("node %s panicked: %v", e.Node, e.Value)
+}
+
+// WithRetry retries the node's function when it returns an error.
+func WithRetry(opts RetryOptions) NodeOption {
+	return func(o *nodeOptions) {
+		o.retry = &opts
+	}
+}
+
+// WithTimeout bounds each attempt of the node's function by the given duration.
+func WithTimeout(timeout time.Duration) NodeOption {
+	return func(o *nodeOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRecover converts a panic in the node's function into a *PanicError.
+//
+// Deprecated: every node recovers from panics; this option has no effect.
+func WithRecover() NodeOption {
+	return func(o *nodeOptions) {}
+}
+
+// WithFallback runs fallback when the node's function fails, after any retries
+// are exhausted. If fallback returns nil the flow continues as if the node had
+// succeeded; otherwise its error fails the node.
+func WithFallback(fallback func(context.Context, error) error) NodeOption {
+	return func(o *nodeOptions) {
+		o.fallback = fallback
+	}
+}
+
+// WithTags attaches key/value metadata to the node. Tags can be read by
+// interceptors using Tags.
+func WithTags(tags map[string]string) NodeOption {
+	return func(o *nodeOptions) {
+		if o.tags == nil {
+			o.tags = make(map[string]string, len(tags))
+		}
+		for k, v := range tags {
+			o.tags[k] = v
+		}
+	}
+}
+
+// Tags returns the tags attached to a node, or nil if it has none.
+func Tags(node Node) map[string]string {
+	if n, ok := node.(*doNode); ok {
+		return n.opts.tags
+	}
+	return nil
+}
+
+func newNodeOptions(opts []NodeOption) nodeOptions {
+	var o nodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// exec runs the node's function, falling back to the configured fallback on failure.
+func (n *doNode) exec(ctx context.Context) error {
+	err := n.execWithRetry(ctx)
+	if err != nil && n.opts.fallback != nil {
+		return n.opts.fallback(ctx, err)
+	}
+	return err
+}
+
+// execWithRetry runs the node's function, applying the configured retry policy.
+func (n *doNode) execWithRetry(ctx context.Context) error {
+	retry := n.opts.retry
+	if retry == nil || retry.MaxAttempts <= 1 {
+		return n.attempt(ctx)
+	}
+
+	backoff := retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err := n.attempt(ctx)
+		if err == nil || attempt >= retry.MaxAttempts {
+			return err
+		}
+		if retry.RetryIf != nil && !retry.RetryIf(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clockFrom(ctx).After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// attempt runs the node's function once, applying the timeout option. A
+// panic in the function is returned as a *PanicError.
+func (n *doNode) attempt(ctx context.Context) (err error) {
+	if n.opts.timeout <= 0 {
+		defer recoverPanic(n.name, &err)
+		return n.fn(ctx)
+	}
+
+	ctx, cancel := withClockTimeout(ctx, clockFrom(ctx), n.opts.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		defer func() { done <- err }()
+		defer recoverPanic(n.name, &err)
+		err = n.fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if context.Cause(ctx) == context.DeadlineExceeded {
+			return fmt.Errorf("node %s timed out after %s: %w", n.name, n.opts.timeout, context.DeadlineExceeded)
+		}
+		return ctx.Err()
+	}
+}
+
+// recoverPanic, when deferred, converts a panic into a *PanicError for the
+// named node and stores it in err.
+func recoverPanic(node string, err *error) {
+	if r := recover(); r != nil {
+		*err = &PanicError{Node: node, Value: r, Stack: debug.Stack()}
+	}
+}
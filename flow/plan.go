@@ -0,0 +1,57 @@
+package flow
+
+import "context"
+
+// NodeInfo describes a node in a flow's execution plan.
+type NodeInfo struct {
+	Name string
+	// Kind is the node's kind, as returned by Kind.
+	Kind string
+	// Parent is the name of the node this one is nested in, e.g. the If
+	// whose branch it belongs to or the InParallel it runs in, or "" for a
+	// node of the flow itself.
+	Parent string
+	// Depth is the nesting level of the node, 0 for a node of the flow itself.
+	Depth int
+}
+
+// Plan returns the nodes the flow would run, in order, without running any of
+// them. Nested nodes follow the node they are nested in. Conditions are not
+// evaluated, so the nodes of every If branch are listed.
+func (f *Flow) Plan(ctx context.Context) []NodeInfo {
+	var plan []NodeInfo
+	planChain(&plan, f.head, f.tail, "", 0)
+	return plan
+}
+
+// planChain appends the nodes from n through last, or to the end of the chain
+// if last is nil.
+func planChain(plan *[]NodeInfo, n Node, last Node, parent string, depth int) {
+	for n != nil {
+		*plan = append(*plan, NodeInfo{Name: n.Name(), Kind: Kind(n), Parent: parent, Depth: depth})
+		switch node := n.(type) {
+		case *conditionalNode:
+			planChain(plan, node.trueBranch, nil, node.name, depth+1)
+		case *sequenceNode:
+			planNodes(plan, node.nodes, node.name, depth+1)
+		case *parallelNode:
+			planNodes(plan, node.nodes, node.name, depth+1)
+		case *backgroundNode:
+			planNodes(plan, node.nodes, node.name, depth+1)
+		case *retryFlowNode:
+			planChain(plan, node.sub, nil, node.name, depth+1)
+		case *Flow:
+			planChain(plan, node.head, node.tail, node.name, depth+1)
+		}
+		if n == last {
+			return
+		}
+		n = n.getNext()
+	}
+}
+
+func planNodes(plan *[]NodeInfo, nodes []Node, parent string, depth int) {
+	for _, n := range nodes {
+		planChain(plan, n, nil, parent, depth)
+	}
+}
@@ -0,0 +1,94 @@
+package flow
+
+import (
+	"context"
+	"errors"
+)
+
+// retryFlowNode runs a sub-flow, re-running it from its head when it fails.
+type retryFlowNode struct {
+	baseNode
+	sub  *Flow
+	opts RetryOptions
+}
+
+// Run runs the sub-flow until it succeeds or the retry options give up.
+func (n *retryFlowNode) run(ctx context.Context, interceptors []Interceptor) error {
+	nodeCtx, done, err := enter(ctx, n, interceptors)
+	if err != nil {
+		return err
+	}
+	err = n.exec(nodeCtx, interceptors)
+	done(err)
+	if err != nil {
+		return err
+	}
+	if n.next != nil {
+		return n.next.run(ctx, interceptors)
+	}
+	return nil
+}
+
+// exec runs the sub-flow up to opts.MaxAttempts times.
+func (n *retryFlowNode) exec(ctx context.Context, interceptors []Interceptor) error {
+	backoff := n.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		err := n.attempt(ctx, interceptors)
+		if err == nil || attempt >= n.opts.MaxAttempts || errors.Is(err, ErrFlowStopped) {
+			return err
+		}
+		if n.opts.RetryIf != nil && !n.opts.RetryIf(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clockFrom(ctx).After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// attempt runs the sub-flow once. Its Defer cleanups run before attempt
+// returns, and if it fails, the saga steps it completed are compensated. Those
+// of a successful or stopped attempt are kept for the enclosing flow to
+// compensate.
+func (n *retryFlowNode) attempt(ctx context.Context, interceptors []Interceptor) (err error) {
+	ctx, cleanup := withCleanupScope(ctx)
+	defer func() { err = withCleanup(err, cleanup) }()
+	attemptCtx, sagas := withSagaLog(ctx)
+
+	err = runRecovered(attemptCtx, n.sub, interceptors)
+	if err != nil && !errors.Is(err, ErrFlowStopped) {
+		if compErr := sagas.compensate(ctx); compErr != nil {
+			err = errors.Join(err, compErr)
+		}
+		return err
+	}
+	if log, ok := ctx.Value(sagaKey{}).(*sagaLog); ok {
+		sagas.mu.Lock()
+		for _, step := range sagas.steps {
+			log.add(step.name, step.compensate)
+		}
+		sagas.mu.Unlock()
+	}
+	return err
+}
+
+// RetryFlow creates a node that runs sub and, when it fails, re-runs it from
+// its head as a unit, e.g. a multi-step saga, according to opts. Each attempt
+// runs its own Defer cleanups, and a failed attempt compensates its saga steps
+// before the next one starts. Cancelling the context aborts between attempts.
+// A MaxAttempts of one or less runs sub once. Unlike the RetryFlow method,
+// which re-runs a whole flow, only sub is repeated.
+func RetryFlow(name string, sub *Flow, opts RetryOptions) Node {
+	return &retryFlowNode{
+		baseNode: baseNode{
+			base: base{
+				name: name,
+			},
+		},
+		sub:  sub,
+		opts: opts,
+	}
+}
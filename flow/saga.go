@@ -0,0 +1,79 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// sagaLog records the compensations of the saga steps completed during a flow run.
+type sagaLog struct {
+	mu    sync.Mutex
+	steps []sagaStep
+}
+
+type sagaStep struct {
+	name       string
+	compensate func(context.Context) error
+}
+
+type sagaKey struct{}
+
+func withSagaLog(ctx context.Context) (context.Context, *sagaLog) {
+	log := &sagaLog{}
+	return context.WithValue(ctx, sagaKey{}, log), log
+}
+
+// run runs the step's compensation, converting a panic into a *PanicError.
+func (s sagaStep) run(ctx context.Context) (err error) {
+	defer recoverPanic(s.name, &err)
+	return s.compensate(ctx)
+}
+
+func (l *sagaLog) add(name string, compensate func(context.Context) error) {
+	l.mu.Lock()
+	l.steps = append(l.steps, sagaStep{name: name, compensate: compensate})
+	l.mu.Unlock()
+}
+
+// compensate runs the recorded compensations in reverse order. Every
+// compensation runs even if an earlier one fails or panics; their errors are
+// joined.
+func (l *sagaLog) compensate(ctx context.Context) error {
+	l.mu.Lock()
+	steps := l.steps
+	l.steps = nil
+	l.mu.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	for i := len(steps) - 1; i >= 0; i-- {
+		if err := steps[i].run(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("compensate %s: %w", steps[i].name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DoSaga creates a saga step node. Once action succeeds, compensate is
+// recorded, and if the flow later fails, the compensations of all completed
+// saga steps run in reverse order before Run returns. Compensation errors are
+// joined with the error that failed the flow.
+func DoSaga(name string, action func(context.Context) error, compensate func(context.Context) error) Node {
+	return Do(name, func(ctx context.Context) error {
+		if err := action(ctx); err != nil {
+			return err
+		}
+		if log, ok := ctx.Value(sagaKey{}).(*sagaLog); ok {
+			log.add(name, compensate)
+		}
+		return nil
+	})
+}
+
+// DoSaga adds a saga step to the flow. See the package-level DoSaga.
+func (f *Flow) DoSaga(name string, action func(context.Context) error, compensate func(context.Context) error) *Flow {
+	f.appendNode(DoSaga(name, action, compensate))
+	return f
+}
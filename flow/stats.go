@@ -0,0 +1,46 @@
+package flow
+
+import (
+	"context"
+	"sync"
+
+	"github.com/micahke/mirage/clients"
+)
+
+// fanOutStats reports how many branches the parallel nodes of a flow start.
+type fanOutStats struct {
+	width    clients.StatsGauge
+	maxWidth clients.StatsGauge
+	mu       sync.Mutex
+	max      int
+}
+
+type statsKey struct{}
+
+// WithStats reports the width of every parallel fan-out in the flow to stats,
+// as the gauges flow_fanout_width, set when a fan-out starts, and
+// flow_fanout_width_max, the widest fan-out observed since the flow was built.
+// Scope stats to tell flows apart.
+func (f *Flow) WithStats(stats clients.StatsClient) *Flow {
+	f.stats = &fanOutStats{
+		width:    stats.Gauge("flow_fanout_width"),
+		maxWidth: stats.Gauge("flow_fanout_width_max"),
+	}
+	return f
+}
+
+// recordFanOut reports a fan-out of width branches if the running flow has
+// stats attached.
+func recordFanOut(ctx context.Context, width int) {
+	stats, ok := ctx.Value(statsKey{}).(*fanOutStats)
+	if !ok {
+		return
+	}
+	stats.width.Set(float64(width))
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	if width > stats.max {
+		stats.max = width
+		stats.maxWidth.Set(float64(width))
+	}
+}
@@ -0,0 +1,64 @@
+package flow
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrFlowStopped is returned by a StopIf node whose condition is true. Run
+// treats it as a deliberate early stop and returns nil.
+var ErrFlowStopped = errors.New("flow stopped")
+
+// stopNode ends the flow early when its condition is true.
+type stopNode struct {
+	baseNode
+	condition func(context.Context) bool
+}
+
+// Run evaluates the condition and stops the flow if it is true.
+func (n *stopNode) run(ctx context.Context, interceptors []Interceptor) error {
+	nodeCtx, done, err := enter(ctx, n, interceptors)
+	if err != nil {
+		return err
+	}
+	stop, err := n.test(nodeCtx)
+	done(err)
+	if err != nil {
+		return err
+	}
+	if stop {
+		return ErrFlowStopped
+	}
+	if n.next != nil {
+		return n.next.run(ctx, interceptors)
+	}
+	return nil
+}
+
+// test evaluates the condition, returning a panic in it as a *PanicError.
+func (n *stopNode) test(ctx context.Context) (stop bool, err error) {
+	defer recoverPanic(n.name, &err)
+	return n.condition(ctx), nil
+}
+
+// StopIf creates a node that stops the flow when cond is true, e.g. when a
+// precondition is not met. No further nodes run, and Run returns nil rather
+// than an error, without compensating saga steps. Inside InParallel, a stop
+// cancels the sibling branches.
+func StopIf(name string, cond func(context.Context) bool) Node {
+	return &stopNode{
+		baseNode: baseNode{
+			base: base{
+				name: name,
+			},
+		},
+		condition: cond,
+	}
+}
+
+// StopIf adds a node that stops the flow when cond is true. See the
+// package-level StopIf.
+func (f *Flow) StopIf(name string, cond func(context.Context) bool) *Flow {
+	f.appendNode(StopIf(name, cond))
+	return f
+}
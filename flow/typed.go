@@ -0,0 +1,49 @@
+package flow
+
+import "context"
+
+// TypedFlow is a flow whose terminal node produces a value of type T.
+// Use Returning to build one from an untyped flow.
+type TypedFlow[T any] struct {
+	flow *Flow
+}
+
+// resultKey identifies the result slot of a TypedFlow in the run context.
+type resultKey[T any] struct {
+	flow *TypedFlow[T]
+}
+
+// Returning completes f with a terminal node that produces the flow's result.
+// Because the terminal function must return a T, a TypedFlow[T] can only be
+// built from a node that actually produces a T. Nodes must not be added to f
+// after it has been wrapped.
+func Returning[T any](f *Flow, name string, fn func(context.Context) (T, error)) *TypedFlow[T] {
+	typed := &TypedFlow[T]{flow: f}
+	key := resultKey[T]{flow: typed}
+	f.Do(name, func(ctx context.Context) error {
+		result, err := fn(ctx)
+		if err != nil {
+			return err
+		}
+		if slot, ok := ctx.Value(key).(*T); ok {
+			*slot = result
+		}
+		return nil
+	})
+	return typed
+}
+
+// Name returns the name of the underlying flow.
+func (t *TypedFlow[T]) Name() string {
+	return t.flow.Name()
+}
+
+// Run executes the flow and returns the value produced by its terminal node.
+func (t *TypedFlow[T]) Run(ctx context.Context) (T, error) {
+	var result T
+	if err := t.flow.Run(context.WithValue(ctx, resultKey[T]{flow: t}, &result)); err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
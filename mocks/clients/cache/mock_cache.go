@@ -84,6 +84,39 @@ func (mr *MockCacheMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCache)(nil).Delete), arg0, arg1)
 }
 
+// DeleteByPattern mocks base method.
+func (m *MockCache) DeleteByPattern(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByPattern", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByPattern indicates an expected call of DeleteByPattern.
+func (mr *MockCacheMockRecorder) DeleteByPattern(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPattern", reflect.TypeOf((*MockCache)(nil).DeleteByPattern), arg0, arg1)
+}
+
+// DeleteMany mocks base method.
+func (m *MockCache) DeleteMany(arg0 context.Context, arg1 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteMany", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockCacheMockRecorder) DeleteMany(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockCache)(nil).DeleteMany), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockCache) Get(arg0 context.Context, arg1 string, arg2 any) error {
 	m.ctrl.T.Helper()
@@ -112,6 +145,20 @@ func (mr *MockCacheMockRecorder) GetMany(arg0, arg1, arg2 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockCache)(nil).GetMany), arg0, arg1, arg2)
 }
 
+// GetOrSet mocks base method.
+func (m *MockCache) GetOrSet(arg0 context.Context, arg1 string, arg2 any, arg3 time.Duration, arg4 func(context.Context) (any, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrSet", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetOrSet indicates an expected call of GetOrSet.
+func (mr *MockCacheMockRecorder) GetOrSet(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrSet", reflect.TypeOf((*MockCache)(nil).GetOrSet), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Incr mocks base method.
 func (m *MockCache) Incr(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
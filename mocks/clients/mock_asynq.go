@@ -109,6 +109,34 @@ func (mr *MockSchedulerClientMockRecorder) Enqueue(task, at any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockSchedulerClient)(nil).Enqueue), task, at)
 }
 
+// EnqueueUnique mocks base method.
+func (m *MockSchedulerClient) EnqueueUnique(task *asynq.Task, at time.Time, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueUnique", task, at, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueUnique indicates an expected call of EnqueueUnique.
+func (mr *MockSchedulerClientMockRecorder) EnqueueUnique(task, at, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueUnique", reflect.TypeOf((*MockSchedulerClient)(nil).EnqueueUnique), task, at, ttl)
+}
+
+// Ping mocks base method.
+func (m *MockSchedulerClient) Ping() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockSchedulerClientMockRecorder) Ping() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockSchedulerClient)(nil).Ping))
+}
+
 // RegisterTask mocks base method.
 func (m *MockSchedulerClient) RegisterTask(name string, task clients.AsynqTask) {
 	m.ctrl.T.Helper()
@@ -121,6 +149,59 @@ func (mr *MockSchedulerClientMockRecorder) RegisterTask(name, task any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTask", reflect.TypeOf((*MockSchedulerClient)(nil).RegisterTask), name, task)
 }
 
+// RegisterTaskWithOptions mocks base method.
+func (m *MockSchedulerClient) RegisterTaskWithOptions(name string, task clients.AsynqTask, opts clients.TaskOptions) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterTaskWithOptions", name, task, opts)
+}
+
+// RegisterTaskWithOptions indicates an expected call of RegisterTaskWithOptions.
+func (mr *MockSchedulerClientMockRecorder) RegisterTaskWithOptions(name, task, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTaskWithOptions", reflect.TypeOf((*MockSchedulerClient)(nil).RegisterTaskWithOptions), name, task, opts)
+}
+
+// Schedule mocks base method.
+func (m *MockSchedulerClient) Schedule(cronspec string, task *asynq.Task) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", cronspec, task)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockSchedulerClientMockRecorder) Schedule(cronspec, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockSchedulerClient)(nil).Schedule), cronspec, task)
+}
+
+// SetErrorHandler mocks base method.
+func (m *MockSchedulerClient) SetErrorHandler(fn func(context.Context, *asynq.Task, error)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetErrorHandler", fn)
+}
+
+// SetErrorHandler indicates an expected call of SetErrorHandler.
+func (mr *MockSchedulerClientMockRecorder) SetErrorHandler(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetErrorHandler", reflect.TypeOf((*MockSchedulerClient)(nil).SetErrorHandler), fn)
+}
+
+// Shutdown mocks base method.
+func (m *MockSchedulerClient) Shutdown() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockSchedulerClientMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockSchedulerClient)(nil).Shutdown))
+}
+
 // Start mocks base method.
 func (m *MockSchedulerClient) Start() error {
 	m.ctrl.T.Helper()
@@ -134,3 +215,17 @@ func (mr *MockSchedulerClientMockRecorder) Start() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockSchedulerClient)(nil).Start))
 }
+
+// Unschedule mocks base method.
+func (m *MockSchedulerClient) Unschedule(entryID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unschedule", entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unschedule indicates an expected call of Unschedule.
+func (mr *MockSchedulerClientMockRecorder) Unschedule(entryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unschedule", reflect.TypeOf((*MockSchedulerClient)(nil).Unschedule), entryID)
+}
@@ -12,6 +12,7 @@ package mock_clients
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	auth "firebase.google.com/go/auth"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,21 @@ func (m *MockFirebaseClient) EXPECT() *MockFirebaseClientMockRecorder {
 	return m.recorder
 }
 
+// CreateSessionCookie mocks base method.
+func (m *MockFirebaseClient) CreateSessionCookie(ctx context.Context, idToken string, expiresIn time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSessionCookie", ctx, idToken, expiresIn)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSessionCookie indicates an expected call of CreateSessionCookie.
+func (mr *MockFirebaseClientMockRecorder) CreateSessionCookie(ctx, idToken, expiresIn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSessionCookie", reflect.TypeOf((*MockFirebaseClient)(nil).CreateSessionCookie), ctx, idToken, expiresIn)
+}
+
 // CreateUser mocks base method.
 func (m *MockFirebaseClient) CreateUser(ctx context.Context, email, password string) (*auth.UserRecord, error) {
 	m.ctrl.T.Helper()
@@ -70,34 +86,59 @@ func (mr *MockFirebaseClientMockRecorder) DeleteUser(ctx, uid any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockFirebaseClient)(nil).DeleteUser), ctx, uid)
 }
 
+// GetCustomClaims mocks base method.
+func (m *MockFirebaseClient) GetCustomClaims(ctx context.Context, uid string) (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomClaims", ctx, uid)
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomClaims indicates an expected call of GetCustomClaims.
+func (mr *MockFirebaseClientMockRecorder) GetCustomClaims(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomClaims", reflect.TypeOf((*MockFirebaseClient)(nil).GetCustomClaims), ctx, uid)
+}
+
 // GetEmailVerificationLink mocks base method.
-func (m *MockFirebaseClient) GetEmailVerificationLink(ctx context.Context, email string) (string, error) {
+func (m *MockFirebaseClient) GetEmailVerificationLink(ctx context.Context, email string, settings ...*auth.ActionCodeSettings) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmailVerificationLink", ctx, email)
+	varargs := []any{ctx, email}
+	for _, a := range settings {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEmailVerificationLink", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetEmailVerificationLink indicates an expected call of GetEmailVerificationLink.
-func (mr *MockFirebaseClientMockRecorder) GetEmailVerificationLink(ctx, email any) *gomock.Call {
+func (mr *MockFirebaseClientMockRecorder) GetEmailVerificationLink(ctx, email any, settings ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationLink", reflect.TypeOf((*MockFirebaseClient)(nil).GetEmailVerificationLink), ctx, email)
+	varargs := append([]any{ctx, email}, settings...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerificationLink", reflect.TypeOf((*MockFirebaseClient)(nil).GetEmailVerificationLink), varargs...)
 }
 
 // GetPasswordResetLink mocks base method.
-func (m *MockFirebaseClient) GetPasswordResetLink(ctx context.Context, email string) (string, error) {
+func (m *MockFirebaseClient) GetPasswordResetLink(ctx context.Context, email string, settings ...*auth.ActionCodeSettings) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPasswordResetLink", ctx, email)
+	varargs := []any{ctx, email}
+	for _, a := range settings {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPasswordResetLink", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPasswordResetLink indicates an expected call of GetPasswordResetLink.
-func (mr *MockFirebaseClientMockRecorder) GetPasswordResetLink(ctx, email any) *gomock.Call {
+func (mr *MockFirebaseClientMockRecorder) GetPasswordResetLink(ctx, email any, settings ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetLink", reflect.TypeOf((*MockFirebaseClient)(nil).GetPasswordResetLink), ctx, email)
+	varargs := append([]any{ctx, email}, settings...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetLink", reflect.TypeOf((*MockFirebaseClient)(nil).GetPasswordResetLink), varargs...)
 }
 
 // GetUser mocks base method.
@@ -130,6 +171,52 @@ func (mr *MockFirebaseClientMockRecorder) GetUserByEmail(ctx, email any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockFirebaseClient)(nil).GetUserByEmail), ctx, email)
 }
 
+// GetUsers mocks base method.
+func (m *MockFirebaseClient) GetUsers(ctx context.Context, uids []string) ([]*auth.UserRecord, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, uids)
+	ret0, _ := ret[0].([]*auth.UserRecord)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockFirebaseClientMockRecorder) GetUsers(ctx, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockFirebaseClient)(nil).GetUsers), ctx, uids)
+}
+
+// GetUsersByEmail mocks base method.
+func (m *MockFirebaseClient) GetUsersByEmail(ctx context.Context, emails []string) ([]*auth.UserRecord, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByEmail", ctx, emails)
+	ret0, _ := ret[0].([]*auth.UserRecord)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsersByEmail indicates an expected call of GetUsersByEmail.
+func (mr *MockFirebaseClientMockRecorder) GetUsersByEmail(ctx, emails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByEmail", reflect.TypeOf((*MockFirebaseClient)(nil).GetUsersByEmail), ctx, emails)
+}
+
+// SetCustomClaims mocks base method.
+func (m *MockFirebaseClient) SetCustomClaims(ctx context.Context, uid string, claims map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCustomClaims", ctx, uid, claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCustomClaims indicates an expected call of SetCustomClaims.
+func (mr *MockFirebaseClientMockRecorder) SetCustomClaims(ctx, uid, claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCustomClaims", reflect.TypeOf((*MockFirebaseClient)(nil).SetCustomClaims), ctx, uid, claims)
+}
+
 // SetDisplayName mocks base method.
 func (m *MockFirebaseClient) SetDisplayName(ctx context.Context, uid, displayName string) error {
 	m.ctrl.T.Helper()
@@ -159,6 +246,21 @@ func (mr *MockFirebaseClientMockRecorder) UpdateUser(ctx, uid, user any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockFirebaseClient)(nil).UpdateUser), ctx, uid, user)
 }
 
+// VerifyIDTokenAndCheckRevoked mocks base method.
+func (m *MockFirebaseClient) VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (*auth.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyIDTokenAndCheckRevoked", ctx, idToken)
+	ret0, _ := ret[0].(*auth.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyIDTokenAndCheckRevoked indicates an expected call of VerifyIDTokenAndCheckRevoked.
+func (mr *MockFirebaseClientMockRecorder) VerifyIDTokenAndCheckRevoked(ctx, idToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyIDTokenAndCheckRevoked", reflect.TypeOf((*MockFirebaseClient)(nil).VerifyIDTokenAndCheckRevoked), ctx, idToken)
+}
+
 // VerifyIdToken mocks base method.
 func (m *MockFirebaseClient) VerifyIdToken(ctx context.Context, idToken string) (*auth.Token, error) {
 	m.ctrl.T.Helper()
@@ -173,3 +275,33 @@ func (mr *MockFirebaseClientMockRecorder) VerifyIdToken(ctx, idToken any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyIdToken", reflect.TypeOf((*MockFirebaseClient)(nil).VerifyIdToken), ctx, idToken)
 }
+
+// VerifySessionCookie mocks base method.
+func (m *MockFirebaseClient) VerifySessionCookie(ctx context.Context, cookie string) (*auth.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySessionCookie", ctx, cookie)
+	ret0, _ := ret[0].(*auth.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySessionCookie indicates an expected call of VerifySessionCookie.
+func (mr *MockFirebaseClientMockRecorder) VerifySessionCookie(ctx, cookie any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySessionCookie", reflect.TypeOf((*MockFirebaseClient)(nil).VerifySessionCookie), ctx, cookie)
+}
+
+// VerifySessionCookieAndCheckRevoked mocks base method.
+func (m *MockFirebaseClient) VerifySessionCookieAndCheckRevoked(ctx context.Context, cookie string) (*auth.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySessionCookieAndCheckRevoked", ctx, cookie)
+	ret0, _ := ret[0].(*auth.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySessionCookieAndCheckRevoked indicates an expected call of VerifySessionCookieAndCheckRevoked.
+func (mr *MockFirebaseClientMockRecorder) VerifySessionCookieAndCheckRevoked(ctx, cookie any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySessionCookieAndCheckRevoked", reflect.TypeOf((*MockFirebaseClient)(nil).VerifySessionCookieAndCheckRevoked), ctx, cookie)
+}
@@ -10,6 +10,7 @@
 package mock_clients
 
 import (
+	context "context"
 	reflect "reflect"
 
 	clients "github.com/micahke/mirage/clients"
@@ -138,3 +139,17 @@ func (mr *MockLoggerMockRecorder) Warn(msg any, keysAndValues ...any) *gomock.Ca
 	varargs := append([]any{msg}, keysAndValues...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockLogger)(nil).Warn), varargs...)
 }
+
+// WithContext mocks base method.
+func (m *MockLogger) WithContext(ctx context.Context) clients.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(clients.Logger)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockLoggerMockRecorder) WithContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockLogger)(nil).WithContext), ctx)
+}
@@ -11,9 +11,12 @@ package mock_clients
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	clients "github.com/micahke/mirage/clients"
+	bson "go.mongodb.org/mongo-driver/bson"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 	mongo "go.mongodb.org/mongo-driver/mongo"
 	options "go.mongodb.org/mongo-driver/mongo/options"
 	gomock "go.uber.org/mock/gomock"
@@ -96,6 +99,70 @@ func (mr *MockMongoCollectionMockRecorder) Aggregate(ctx, pipeline, results any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockMongoCollection)(nil).Aggregate), ctx, pipeline, results)
 }
 
+// AggregateToCollection mocks base method.
+func (m *MockMongoCollection) AggregateToCollection(ctx context.Context, pipeline any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateToCollection", ctx, pipeline)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AggregateToCollection indicates an expected call of AggregateToCollection.
+func (mr *MockMongoCollectionMockRecorder) AggregateToCollection(ctx, pipeline any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateToCollection", reflect.TypeOf((*MockMongoCollection)(nil).AggregateToCollection), ctx, pipeline)
+}
+
+// BulkWrite mocks base method.
+func (m *MockMongoCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, models}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BulkWrite", varargs...)
+	ret0, _ := ret[0].(*mongo.BulkWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkWrite indicates an expected call of BulkWrite.
+func (mr *MockMongoCollectionMockRecorder) BulkWrite(ctx, models any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, models}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkWrite", reflect.TypeOf((*MockMongoCollection)(nil).BulkWrite), varargs...)
+}
+
+// Count mocks base method.
+func (m *MockMongoCollection) Count(ctx context.Context, filter any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockMongoCollectionMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockMongoCollection)(nil).Count), ctx, filter)
+}
+
+// CreateIndex mocks base method.
+func (m *MockMongoCollection) CreateIndex(ctx context.Context, keys bson.D, unique bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", ctx, keys, unique)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockMongoCollectionMockRecorder) CreateIndex(ctx, keys, unique any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockMongoCollection)(nil).CreateIndex), ctx, keys, unique)
+}
+
 // DeleteMany mocks base method.
 func (m *MockMongoCollection) DeleteMany(ctx context.Context, filter any) (*mongo.DeleteResult, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +193,35 @@ func (mr *MockMongoCollectionMockRecorder) DeleteOne(ctx, filter any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOne", reflect.TypeOf((*MockMongoCollection)(nil).DeleteOne), ctx, filter)
 }
 
+// Distinct mocks base method.
+func (m *MockMongoCollection) Distinct(ctx context.Context, fieldName string, filter any) ([]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distinct", ctx, fieldName, filter)
+	ret0, _ := ret[0].([]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Distinct indicates an expected call of Distinct.
+func (mr *MockMongoCollectionMockRecorder) Distinct(ctx, fieldName, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distinct", reflect.TypeOf((*MockMongoCollection)(nil).Distinct), ctx, fieldName, filter)
+}
+
+// EnsureIndexes mocks base method.
+func (m *MockMongoCollection) EnsureIndexes(ctx context.Context, models []mongo.IndexModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureIndexes", ctx, models)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureIndexes indicates an expected call of EnsureIndexes.
+func (mr *MockMongoCollectionMockRecorder) EnsureIndexes(ctx, models any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureIndexes", reflect.TypeOf((*MockMongoCollection)(nil).EnsureIndexes), ctx, models)
+}
+
 // Exists mocks base method.
 func (m *MockMongoCollection) Exists(ctx context.Context, filter any) (bool, error) {
 	m.ctrl.T.Helper()
@@ -175,21 +271,21 @@ func (mr *MockMongoCollectionMockRecorder) FindOne(ctx, filter, result any) *gom
 }
 
 // FindOneAndUpdate mocks base method.
-func (m *MockMongoCollection) FindOneAndUpdate(ctx context.Context, filter, update any, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+func (m *MockMongoCollection) FindOneAndUpdate(ctx context.Context, filter, update, result any, opts ...*options.FindOneAndUpdateOptions) error {
 	m.ctrl.T.Helper()
-	varargs := []any{ctx, filter, update}
+	varargs := []any{ctx, filter, update, result}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "FindOneAndUpdate", varargs...)
-	ret0, _ := ret[0].(*mongo.SingleResult)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // FindOneAndUpdate indicates an expected call of FindOneAndUpdate.
-func (mr *MockMongoCollectionMockRecorder) FindOneAndUpdate(ctx, filter, update any, opts ...any) *gomock.Call {
+func (mr *MockMongoCollectionMockRecorder) FindOneAndUpdate(ctx, filter, update, result any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx, filter, update}, opts...)
+	varargs := append([]any{ctx, filter, update, result}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneAndUpdate", reflect.TypeOf((*MockMongoCollection)(nil).FindOneAndUpdate), varargs...)
 }
 
@@ -235,18 +331,39 @@ func (mr *MockMongoCollectionMockRecorder) InsertOne(ctx, document any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOne", reflect.TypeOf((*MockMongoCollection)(nil).InsertOne), ctx, document)
 }
 
+// InsertOneID mocks base method.
+func (m *MockMongoCollection) InsertOneID(ctx context.Context, document any) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOneID", ctx, document)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertOneID indicates an expected call of InsertOneID.
+func (mr *MockMongoCollectionMockRecorder) InsertOneID(ctx, document any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOneID", reflect.TypeOf((*MockMongoCollection)(nil).InsertOneID), ctx, document)
+}
+
 // ReplaceOne mocks base method.
-func (m *MockMongoCollection) ReplaceOne(ctx context.Context, filter, replacement any) error {
+func (m *MockMongoCollection) ReplaceOne(ctx context.Context, filter, replacement any, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReplaceOne", ctx, filter, replacement)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []any{ctx, filter, replacement}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReplaceOne", varargs...)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ReplaceOne indicates an expected call of ReplaceOne.
-func (mr *MockMongoCollectionMockRecorder) ReplaceOne(ctx, filter, replacement any) *gomock.Call {
+func (mr *MockMongoCollectionMockRecorder) ReplaceOne(ctx, filter, replacement any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOne", reflect.TypeOf((*MockMongoCollection)(nil).ReplaceOne), ctx, filter, replacement)
+	varargs := append([]any{ctx, filter, replacement}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOne", reflect.TypeOf((*MockMongoCollection)(nil).ReplaceOne), varargs...)
 }
 
 // UpdateMany mocks base method.
@@ -284,6 +401,104 @@ func (mr *MockMongoCollectionMockRecorder) UpdateOne(ctx, filter, update any, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockMongoCollection)(nil).UpdateOne), varargs...)
 }
 
+// Upsert mocks base method.
+func (m *MockMongoCollection) Upsert(ctx context.Context, filter, update any) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, filter, update)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockMongoCollectionMockRecorder) Upsert(ctx, filter, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockMongoCollection)(nil).Upsert), ctx, filter, update)
+}
+
+// Watch mocks base method.
+func (m *MockMongoCollection) Watch(ctx context.Context, pipeline any) (<-chan bson.M, <-chan error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, pipeline)
+	ret0, _ := ret[0].(<-chan bson.M)
+	ret1, _ := ret[1].(<-chan error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockMongoCollectionMockRecorder) Watch(ctx, pipeline any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockMongoCollection)(nil).Watch), ctx, pipeline)
+}
+
+// MockMongoGridFS is a mock of MongoGridFS interface.
+type MockMongoGridFS struct {
+	ctrl     *gomock.Controller
+	recorder *MockMongoGridFSMockRecorder
+	isgomock struct{}
+}
+
+// MockMongoGridFSMockRecorder is the mock recorder for MockMongoGridFS.
+type MockMongoGridFSMockRecorder struct {
+	mock *MockMongoGridFS
+}
+
+// NewMockMongoGridFS creates a new mock instance.
+func NewMockMongoGridFS(ctrl *gomock.Controller) *MockMongoGridFS {
+	mock := &MockMongoGridFS{ctrl: ctrl}
+	mock.recorder = &MockMongoGridFSMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMongoGridFS) EXPECT() *MockMongoGridFSMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMongoGridFS) Delete(ctx context.Context, fileID any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, fileID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMongoGridFSMockRecorder) Delete(ctx, fileID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMongoGridFS)(nil).Delete), ctx, fileID)
+}
+
+// Download mocks base method.
+func (m *MockMongoGridFS) Download(ctx context.Context, fileID any, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", ctx, fileID, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockMongoGridFSMockRecorder) Download(ctx, fileID, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockMongoGridFS)(nil).Download), ctx, fileID, w)
+}
+
+// Upload mocks base method.
+func (m *MockMongoGridFS) Upload(ctx context.Context, filename string, r io.Reader) (primitive.ObjectID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, filename, r)
+	ret0, _ := ret[0].(primitive.ObjectID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockMongoGridFSMockRecorder) Upload(ctx, filename, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockMongoGridFS)(nil).Upload), ctx, filename, r)
+}
+
 // MockMongoClient is a mock of MongoClient interface.
 type MockMongoClient struct {
 	ctrl     *gomock.Controller
@@ -322,6 +537,40 @@ func (mr *MockMongoClientMockRecorder) Aggregate(ctx, req, results any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockMongoClient)(nil).Aggregate), ctx, req, results)
 }
 
+// AggregateToCollection mocks base method.
+func (m *MockMongoClient) AggregateToCollection(ctx context.Context, req *clients.AggregateRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateToCollection", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AggregateToCollection indicates an expected call of AggregateToCollection.
+func (mr *MockMongoClientMockRecorder) AggregateToCollection(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateToCollection", reflect.TypeOf((*MockMongoClient)(nil).AggregateToCollection), ctx, req)
+}
+
+// BulkWrite mocks base method.
+func (m *MockMongoClient) BulkWrite(ctx context.Context, req *clients.BulkWriteRequest, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, req}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BulkWrite", varargs...)
+	ret0, _ := ret[0].(*mongo.BulkWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkWrite indicates an expected call of BulkWrite.
+func (mr *MockMongoClientMockRecorder) BulkWrite(ctx, req any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, req}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkWrite", reflect.TypeOf((*MockMongoClient)(nil).BulkWrite), varargs...)
+}
+
 // Collection mocks base method.
 func (m *MockMongoClient) Collection(database, collection string) clients.MongoCollection {
 	m.ctrl.T.Helper()
@@ -336,6 +585,21 @@ func (mr *MockMongoClientMockRecorder) Collection(database, collection any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collection", reflect.TypeOf((*MockMongoClient)(nil).Collection), database, collection)
 }
 
+// Count mocks base method.
+func (m *MockMongoClient) Count(ctx context.Context, req *clients.CountRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, req)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockMongoClientMockRecorder) Count(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockMongoClient)(nil).Count), ctx, req)
+}
+
 // Disconnect mocks base method.
 func (m *MockMongoClient) Disconnect(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -350,6 +614,21 @@ func (mr *MockMongoClientMockRecorder) Disconnect(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockMongoClient)(nil).Disconnect), ctx)
 }
 
+// Distinct mocks base method.
+func (m *MockMongoClient) Distinct(ctx context.Context, req *clients.DistinctRequest) ([]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distinct", ctx, req)
+	ret0, _ := ret[0].([]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Distinct indicates an expected call of Distinct.
+func (mr *MockMongoClientMockRecorder) Distinct(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distinct", reflect.TypeOf((*MockMongoClient)(nil).Distinct), ctx, req)
+}
+
 // Exists mocks base method.
 func (m *MockMongoClient) Exists(ctx context.Context, req *clients.ExistsRequest) (bool, error) {
 	m.ctrl.T.Helper()
@@ -398,6 +677,49 @@ func (mr *MockMongoClientMockRecorder) FindOne(ctx, req, result any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockMongoClient)(nil).FindOne), ctx, req, result)
 }
 
+// FindOneAndUpdate mocks base method.
+func (m *MockMongoClient) FindOneAndUpdate(ctx context.Context, req *clients.FindOneAndUpdateRequest, result any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneAndUpdate", ctx, req, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindOneAndUpdate indicates an expected call of FindOneAndUpdate.
+func (mr *MockMongoClientMockRecorder) FindOneAndUpdate(ctx, req, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneAndUpdate", reflect.TypeOf((*MockMongoClient)(nil).FindOneAndUpdate), ctx, req, result)
+}
+
+// FindPaginated mocks base method.
+func (m *MockMongoClient) FindPaginated(ctx context.Context, req *clients.FindPaginatedRequest, results any) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginated", ctx, req, results)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPaginated indicates an expected call of FindPaginated.
+func (mr *MockMongoClientMockRecorder) FindPaginated(ctx, req, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginated", reflect.TypeOf((*MockMongoClient)(nil).FindPaginated), ctx, req, results)
+}
+
+// GridFS mocks base method.
+func (m *MockMongoClient) GridFS(database, bucket string) clients.MongoGridFS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GridFS", database, bucket)
+	ret0, _ := ret[0].(clients.MongoGridFS)
+	return ret0
+}
+
+// GridFS indicates an expected call of GridFS.
+func (mr *MockMongoClientMockRecorder) GridFS(database, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GridFS", reflect.TypeOf((*MockMongoClient)(nil).GridFS), database, bucket)
+}
+
 // InsertMany mocks base method.
 func (m *MockMongoClient) InsertMany(ctx context.Context, req *clients.InsertManyRequest) error {
 	m.ctrl.T.Helper()
@@ -426,16 +748,89 @@ func (mr *MockMongoClientMockRecorder) InsertOne(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOne", reflect.TypeOf((*MockMongoClient)(nil).InsertOne), ctx, req)
 }
 
-// ReplaceOne mocks base method.
-func (m *MockMongoClient) ReplaceOne(ctx context.Context, req *clients.ReplaceOneRequest) error {
+// InsertOneID mocks base method.
+func (m *MockMongoClient) InsertOneID(ctx context.Context, req *clients.InsertOneRequest) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReplaceOne", ctx, req)
+	ret := m.ctrl.Call(m, "InsertOneID", ctx, req)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertOneID indicates an expected call of InsertOneID.
+func (mr *MockMongoClientMockRecorder) InsertOneID(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOneID", reflect.TypeOf((*MockMongoClient)(nil).InsertOneID), ctx, req)
+}
+
+// Ping mocks base method.
+func (m *MockMongoClient) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Ping indicates an expected call of Ping.
+func (mr *MockMongoClientMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockMongoClient)(nil).Ping), ctx)
+}
+
+// ReplaceOne mocks base method.
+func (m *MockMongoClient) ReplaceOne(ctx context.Context, req *clients.ReplaceOneRequest) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceOne", ctx, req)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // ReplaceOne indicates an expected call of ReplaceOne.
 func (mr *MockMongoClientMockRecorder) ReplaceOne(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOne", reflect.TypeOf((*MockMongoClient)(nil).ReplaceOne), ctx, req)
 }
+
+// UpdateOne mocks base method.
+func (m *MockMongoClient) UpdateOne(ctx context.Context, req *clients.UpdateOneRequest) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOne", ctx, req)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOne indicates an expected call of UpdateOne.
+func (mr *MockMongoClientMockRecorder) UpdateOne(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOne", reflect.TypeOf((*MockMongoClient)(nil).UpdateOne), ctx, req)
+}
+
+// UpdateWithVersion mocks base method.
+func (m *MockMongoClient) UpdateWithVersion(ctx context.Context, req *clients.UpdateWithVersionRequest, versionField string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithVersion", ctx, req, versionField)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWithVersion indicates an expected call of UpdateWithVersion.
+func (mr *MockMongoClientMockRecorder) UpdateWithVersion(ctx, req, versionField any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithVersion", reflect.TypeOf((*MockMongoClient)(nil).UpdateWithVersion), ctx, req, versionField)
+}
+
+// WithTransaction mocks base method.
+func (m *MockMongoClient) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockMongoClientMockRecorder) WithTransaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockMongoClient)(nil).WithTransaction), ctx, fn)
+}
@@ -12,9 +12,11 @@ package mock_clients
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	pgx "github.com/jackc/pgx/v5"
 	pgconn "github.com/jackc/pgx/v5/pgconn"
+	clients "github.com/micahke/mirage/clients"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -69,6 +71,21 @@ func (mr *MockPostgresClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPostgresClient)(nil).Close))
 }
 
+// CopyFrom mocks base method.
+func (m *MockPostgresClient) CopyFrom(ctx context.Context, table string, columns []string, rows [][]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyFrom", ctx, table, columns, rows)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyFrom indicates an expected call of CopyFrom.
+func (mr *MockPostgresClientMockRecorder) CopyFrom(ctx, table, columns, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFrom", reflect.TypeOf((*MockPostgresClient)(nil).CopyFrom), ctx, table, columns, rows)
+}
+
 // Exec mocks base method.
 func (m *MockPostgresClient) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
 	m.ctrl.T.Helper()
@@ -89,6 +106,50 @@ func (mr *MockPostgresClientMockRecorder) Exec(ctx, sql any, args ...any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockPostgresClient)(nil).Exec), varargs...)
 }
 
+// ExecNamed mocks base method.
+func (m *MockPostgresClient) ExecNamed(ctx context.Context, sql string, args pgx.NamedArgs) (pgconn.CommandTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecNamed", ctx, sql, args)
+	ret0, _ := ret[0].(pgconn.CommandTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecNamed indicates an expected call of ExecNamed.
+func (mr *MockPostgresClientMockRecorder) ExecNamed(ctx, sql, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecNamed", reflect.TypeOf((*MockPostgresClient)(nil).ExecNamed), ctx, sql, args)
+}
+
+// Health mocks base method.
+func (m *MockPostgresClient) Health(ctx context.Context) clients.HealthStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health", ctx)
+	ret0, _ := ret[0].(clients.HealthStatus)
+	return ret0
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockPostgresClientMockRecorder) Health(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockPostgresClient)(nil).Health), ctx)
+}
+
+// Listen mocks base method.
+func (m *MockPostgresClient) Listen(ctx context.Context, channel string) (<-chan string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, channel)
+	ret0, _ := ret[0].(<-chan string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockPostgresClientMockRecorder) Listen(ctx, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockPostgresClient)(nil).Listen), ctx, channel)
+}
+
 // Ping mocks base method.
 func (m *MockPostgresClient) Ping(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -123,6 +184,21 @@ func (mr *MockPostgresClientMockRecorder) Query(ctx, sql any, args ...any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockPostgresClient)(nil).Query), varargs...)
 }
 
+// QueryNamed mocks base method.
+func (m *MockPostgresClient) QueryNamed(ctx context.Context, sql string, args pgx.NamedArgs) (pgx.Rows, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryNamed", ctx, sql, args)
+	ret0, _ := ret[0].(pgx.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryNamed indicates an expected call of QueryNamed.
+func (mr *MockPostgresClientMockRecorder) QueryNamed(ctx, sql, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryNamed", reflect.TypeOf((*MockPostgresClient)(nil).QueryNamed), ctx, sql, args)
+}
+
 // QueryRow mocks base method.
 func (m *MockPostgresClient) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
 	m.ctrl.T.Helper()
@@ -141,3 +217,58 @@ func (mr *MockPostgresClientMockRecorder) QueryRow(ctx, sql any, args ...any) *g
 	varargs := append([]any{ctx, sql}, args...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRow", reflect.TypeOf((*MockPostgresClient)(nil).QueryRow), varargs...)
 }
+
+// QueryRowNamed mocks base method.
+func (m *MockPostgresClient) QueryRowNamed(ctx context.Context, sql string, args pgx.NamedArgs) pgx.Row {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryRowNamed", ctx, sql, args)
+	ret0, _ := ret[0].(pgx.Row)
+	return ret0
+}
+
+// QueryRowNamed indicates an expected call of QueryRowNamed.
+func (mr *MockPostgresClientMockRecorder) QueryRowNamed(ctx, sql, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowNamed", reflect.TypeOf((*MockPostgresClient)(nil).QueryRowNamed), ctx, sql, args)
+}
+
+// SendBatch mocks base method.
+func (m *MockPostgresClient) SendBatch(ctx context.Context, batch *pgx.Batch) (pgx.BatchResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBatch", ctx, batch)
+	ret0, _ := ret[0].(pgx.BatchResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBatch indicates an expected call of SendBatch.
+func (mr *MockPostgresClientMockRecorder) SendBatch(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBatch", reflect.TypeOf((*MockPostgresClient)(nil).SendBatch), ctx, batch)
+}
+
+// StartPoolMetrics mocks base method.
+func (m *MockPostgresClient) StartPoolMetrics(ctx context.Context, stats clients.StatsClient, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StartPoolMetrics", ctx, stats, interval)
+}
+
+// StartPoolMetrics indicates an expected call of StartPoolMetrics.
+func (mr *MockPostgresClientMockRecorder) StartPoolMetrics(ctx, stats, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartPoolMetrics", reflect.TypeOf((*MockPostgresClient)(nil).StartPoolMetrics), ctx, stats, interval)
+}
+
+// WithTxRetry mocks base method.
+func (m *MockPostgresClient) WithTxRetry(ctx context.Context, maxAttempts int, fn func(pgx.Tx) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTxRetry", ctx, maxAttempts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTxRetry indicates an expected call of WithTxRetry.
+func (mr *MockPostgresClientMockRecorder) WithTxRetry(ctx, maxAttempts, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTxRetry", reflect.TypeOf((*MockPostgresClient)(nil).WithTxRetry), ctx, maxAttempts, fn)
+}
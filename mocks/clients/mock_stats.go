@@ -52,6 +52,186 @@ func (mr *MockStatsCounterMockRecorder) Inc() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inc", reflect.TypeOf((*MockStatsCounter)(nil).Inc))
 }
 
+// MockStatsCounterVec is a mock of StatsCounterVec interface.
+type MockStatsCounterVec struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsCounterVecMockRecorder
+	isgomock struct{}
+}
+
+// MockStatsCounterVecMockRecorder is the mock recorder for MockStatsCounterVec.
+type MockStatsCounterVecMockRecorder struct {
+	mock *MockStatsCounterVec
+}
+
+// NewMockStatsCounterVec creates a new mock instance.
+func NewMockStatsCounterVec(ctrl *gomock.Controller) *MockStatsCounterVec {
+	mock := &MockStatsCounterVec{ctrl: ctrl}
+	mock.recorder = &MockStatsCounterVecMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsCounterVec) EXPECT() *MockStatsCounterVecMockRecorder {
+	return m.recorder
+}
+
+// With mocks base method.
+func (m *MockStatsCounterVec) With(labelValues ...string) clients.StatsCounter {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range labelValues {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "With", varargs...)
+	ret0, _ := ret[0].(clients.StatsCounter)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockStatsCounterVecMockRecorder) With(labelValues ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*MockStatsCounterVec)(nil).With), labelValues...)
+}
+
+// MockStatsGauge is a mock of StatsGauge interface.
+type MockStatsGauge struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsGaugeMockRecorder
+	isgomock struct{}
+}
+
+// MockStatsGaugeMockRecorder is the mock recorder for MockStatsGauge.
+type MockStatsGaugeMockRecorder struct {
+	mock *MockStatsGauge
+}
+
+// NewMockStatsGauge creates a new mock instance.
+func NewMockStatsGauge(ctrl *gomock.Controller) *MockStatsGauge {
+	mock := &MockStatsGauge{ctrl: ctrl}
+	mock.recorder = &MockStatsGaugeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsGauge) EXPECT() *MockStatsGaugeMockRecorder {
+	return m.recorder
+}
+
+// Dec mocks base method.
+func (m *MockStatsGauge) Dec() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Dec")
+}
+
+// Dec indicates an expected call of Dec.
+func (mr *MockStatsGaugeMockRecorder) Dec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dec", reflect.TypeOf((*MockStatsGauge)(nil).Dec))
+}
+
+// Inc mocks base method.
+func (m *MockStatsGauge) Inc() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Inc")
+}
+
+// Inc indicates an expected call of Inc.
+func (mr *MockStatsGaugeMockRecorder) Inc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inc", reflect.TypeOf((*MockStatsGauge)(nil).Inc))
+}
+
+// Set mocks base method.
+func (m *MockStatsGauge) Set(arg0 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", arg0)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockStatsGaugeMockRecorder) Set(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockStatsGauge)(nil).Set), arg0)
+}
+
+// MockStatsHistogram is a mock of StatsHistogram interface.
+type MockStatsHistogram struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsHistogramMockRecorder
+	isgomock struct{}
+}
+
+// MockStatsHistogramMockRecorder is the mock recorder for MockStatsHistogram.
+type MockStatsHistogramMockRecorder struct {
+	mock *MockStatsHistogram
+}
+
+// NewMockStatsHistogram creates a new mock instance.
+func NewMockStatsHistogram(ctrl *gomock.Controller) *MockStatsHistogram {
+	mock := &MockStatsHistogram{ctrl: ctrl}
+	mock.recorder = &MockStatsHistogramMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsHistogram) EXPECT() *MockStatsHistogramMockRecorder {
+	return m.recorder
+}
+
+// Observe mocks base method.
+func (m *MockStatsHistogram) Observe(arg0 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Observe", arg0)
+}
+
+// Observe indicates an expected call of Observe.
+func (mr *MockStatsHistogramMockRecorder) Observe(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Observe", reflect.TypeOf((*MockStatsHistogram)(nil).Observe), arg0)
+}
+
+// MockStatsHistogramVec is a mock of StatsHistogramVec interface.
+type MockStatsHistogramVec struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsHistogramVecMockRecorder
+	isgomock struct{}
+}
+
+// MockStatsHistogramVecMockRecorder is the mock recorder for MockStatsHistogramVec.
+type MockStatsHistogramVecMockRecorder struct {
+	mock *MockStatsHistogramVec
+}
+
+// NewMockStatsHistogramVec creates a new mock instance.
+func NewMockStatsHistogramVec(ctrl *gomock.Controller) *MockStatsHistogramVec {
+	mock := &MockStatsHistogramVec{ctrl: ctrl}
+	mock.recorder = &MockStatsHistogramVecMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsHistogramVec) EXPECT() *MockStatsHistogramVecMockRecorder {
+	return m.recorder
+}
+
+// With mocks base method.
+func (m *MockStatsHistogramVec) With(labelValues ...string) clients.StatsHistogram {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range labelValues {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "With", varargs...)
+	ret0, _ := ret[0].(clients.StatsHistogram)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockStatsHistogramVecMockRecorder) With(labelValues ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*MockStatsHistogramVec)(nil).With), labelValues...)
+}
+
 // MockStatsClient is a mock of StatsClient interface.
 type MockStatsClient struct {
 	ctrl     *gomock.Controller
@@ -90,6 +270,115 @@ func (mr *MockStatsClientMockRecorder) Counter(name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counter", reflect.TypeOf((*MockStatsClient)(nil).Counter), name)
 }
 
+// CounterVec mocks base method.
+func (m *MockStatsClient) CounterVec(name string, labels ...string) clients.StatsCounterVec {
+	m.ctrl.T.Helper()
+	varargs := []any{name}
+	for _, a := range labels {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CounterVec", varargs...)
+	ret0, _ := ret[0].(clients.StatsCounterVec)
+	return ret0
+}
+
+// CounterVec indicates an expected call of CounterVec.
+func (mr *MockStatsClientMockRecorder) CounterVec(name any, labels ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name}, labels...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CounterVec", reflect.TypeOf((*MockStatsClient)(nil).CounterVec), varargs...)
+}
+
+// CounterWithHelp mocks base method.
+func (m *MockStatsClient) CounterWithHelp(name, help string) clients.StatsCounter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CounterWithHelp", name, help)
+	ret0, _ := ret[0].(clients.StatsCounter)
+	return ret0
+}
+
+// CounterWithHelp indicates an expected call of CounterWithHelp.
+func (mr *MockStatsClientMockRecorder) CounterWithHelp(name, help any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CounterWithHelp", reflect.TypeOf((*MockStatsClient)(nil).CounterWithHelp), name, help)
+}
+
+// Gauge mocks base method.
+func (m *MockStatsClient) Gauge(name string) clients.StatsGauge {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gauge", name)
+	ret0, _ := ret[0].(clients.StatsGauge)
+	return ret0
+}
+
+// Gauge indicates an expected call of Gauge.
+func (mr *MockStatsClientMockRecorder) Gauge(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gauge", reflect.TypeOf((*MockStatsClient)(nil).Gauge), name)
+}
+
+// Histogram mocks base method.
+func (m *MockStatsClient) Histogram(name string, buckets []float64) clients.StatsHistogram {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Histogram", name, buckets)
+	ret0, _ := ret[0].(clients.StatsHistogram)
+	return ret0
+}
+
+// Histogram indicates an expected call of Histogram.
+func (mr *MockStatsClientMockRecorder) Histogram(name, buckets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Histogram", reflect.TypeOf((*MockStatsClient)(nil).Histogram), name, buckets)
+}
+
+// HistogramVec mocks base method.
+func (m *MockStatsClient) HistogramVec(name string, buckets []float64, labels ...string) clients.StatsHistogramVec {
+	m.ctrl.T.Helper()
+	varargs := []any{name, buckets}
+	for _, a := range labels {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HistogramVec", varargs...)
+	ret0, _ := ret[0].(clients.StatsHistogramVec)
+	return ret0
+}
+
+// HistogramVec indicates an expected call of HistogramVec.
+func (mr *MockStatsClientMockRecorder) HistogramVec(name, buckets any, labels ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name, buckets}, labels...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistogramVec", reflect.TypeOf((*MockStatsClient)(nil).HistogramVec), varargs...)
+}
+
+// RegisterCounter mocks base method.
+func (m *MockStatsClient) RegisterCounter(name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterCounter", name)
+}
+
+// RegisterCounter indicates an expected call of RegisterCounter.
+func (mr *MockStatsClientMockRecorder) RegisterCounter(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCounter", reflect.TypeOf((*MockStatsClient)(nil).RegisterCounter), name)
+}
+
+// RegisterCounterVec mocks base method.
+func (m *MockStatsClient) RegisterCounterVec(name string, labels []string, values ...[]string) {
+	m.ctrl.T.Helper()
+	varargs := []any{name, labels}
+	for _, a := range values {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "RegisterCounterVec", varargs...)
+}
+
+// RegisterCounterVec indicates an expected call of RegisterCounterVec.
+func (mr *MockStatsClientMockRecorder) RegisterCounterVec(name, labels any, values ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name, labels}, values...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCounterVec", reflect.TypeOf((*MockStatsClient)(nil).RegisterCounterVec), varargs...)
+}
+
 // Scope mocks base method.
 func (m *MockStatsClient) Scope(scopes ...string) clients.StatsClient {
 	m.ctrl.T.Helper()
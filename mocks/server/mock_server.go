@@ -10,6 +10,7 @@
 package mock_server
 
 import (
+	context "context"
 	reflect "reflect"
 
 	server "github.com/micahke/mirage/server"
@@ -66,6 +67,20 @@ func (mr *MockServerMockRecorder) RegisterRoutes(routes any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockServer)(nil).RegisterRoutes), routes)
 }
 
+// Shutdown mocks base method.
+func (m *MockServer) Shutdown(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockServerMockRecorder) Shutdown(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockServer)(nil).Shutdown), ctx)
+}
+
 // Start mocks base method.
 func (m *MockServer) Start() error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"firebase.google.com/go/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/micahke/mirage/clients"
+)
+
+// UserContextKey is the gin context key AuthMiddleware stores the verified
+// *auth.Token under.
+const UserContextKey = "firebase_user"
+
+// AuthMiddleware verifies the Firebase ID token in the request's
+// "Authorization: Bearer <token>" header and stores it on the gin context,
+// where handlers can read it with UserFromContext. Requests without a valid
+// token are aborted with 401.
+func AuthMiddleware(fb clients.FirebaseClient) gin.HandlerFunc {
+	return authMiddleware(fb.VerifyIdToken)
+}
+
+// AuthMiddlewareCheckRevoked is like AuthMiddleware but also rejects tokens
+// that have been revoked, or whose user has been disabled. It costs a call to
+// Firebase per request.
+func AuthMiddlewareCheckRevoked(fb clients.FirebaseClient) gin.HandlerFunc {
+	return authMiddleware(fb.VerifyIDTokenAndCheckRevoked)
+}
+
+func authMiddleware(verify func(context.Context, string) (*auth.Token, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		idToken, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || idToken == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+			return
+		}
+
+		token, err := verify(c.Request.Context(), idToken)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+
+		c.Set(UserContextKey, token)
+		c.Next()
+	}
+}
+
+// UserFromContext returns the token stored by AuthMiddleware, or false if the
+// request did not pass through it.
+func UserFromContext(c *gin.Context) (*auth.Token, bool) {
+	value, ok := c.Get(UserContextKey)
+	if !ok {
+		return nil, false
+	}
+	token, ok := value.(*auth.Token)
+	return token, ok
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type readinessProbe struct {
+	name  string
+	probe func(context.Context) error
+}
+
+// RegisterReadinessProbe adds a check run by the readiness endpoint, e.g. a
+// database client's Ping.
+func (s *HttpServer) RegisterReadinessProbe(name string, probe func(context.Context) error) {
+	s.probesMu.Lock()
+	s.probes = append(s.probes, readinessProbe{name: name, probe: probe})
+	s.probesMu.Unlock()
+}
+
+// EnableHealthChecks registers GET /healthz, which responds 200 while the
+// process is serving, and GET /readyz, which runs every readiness probe and
+// responds 503 listing the failures if any probe fails.
+func (s *HttpServer) EnableHealthChecks() {
+	s.router.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	s.router.GET("/readyz", s.ready)
+}
+
+func (s *HttpServer) ready(c *gin.Context) {
+	s.probesMu.Lock()
+	probes := append([]readinessProbe(nil), s.probes...)
+	s.probesMu.Unlock()
+
+	failures := make(map[string]string)
+	for _, p := range probes {
+		if err := p.probe(c.Request.Context()); err != nil {
+			failures[p.name] = err.Error()
+		}
+	}
+
+	if len(failures) > 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "failures": failures})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
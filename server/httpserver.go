@@ -1,7 +1,12 @@
 package server
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -10,6 +15,13 @@ import (
 type HttpServer struct {
 	port   int
 	router *gin.Engine
+	srv    *http.Server
+
+	shutdownOnce sync.Once
+	shutdownDone chan struct{}
+
+	probesMu sync.Mutex
+	probes   []readinessProbe
 }
 
 func NewHttpServer(port int) *HttpServer {
@@ -19,11 +31,52 @@ func NewHttpServer(port int) *HttpServer {
 	return &HttpServer{
 		port:   port,
 		router: r,
+		srv: &http.Server{
+			Addr:    fmt.Sprintf(":%d", port),
+			Handler: r,
+		},
+		shutdownDone: make(chan struct{}),
 	}
 }
 
+// Start serves HTTP until the server fails or Shutdown is called. After
+// Shutdown, it returns nil once in-flight requests have drained.
 func (s *HttpServer) Start() error {
-	return s.router.Run(fmt.Sprintf(":%d", s.port))
+	return s.wait(s.srv.ListenAndServe())
+}
+
+// StartTLS serves HTTPS using the certificate and key in the given files,
+// until the server fails or Shutdown is called. Connections use the config set
+// with SetTLSConfig, or require TLS 1.2 or later if none was set.
+func (s *HttpServer) StartTLS(certFile, keyFile string) error {
+	if s.srv.TLSConfig == nil {
+		s.srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return s.wait(s.srv.ListenAndServeTLS(certFile, keyFile))
+}
+
+// SetTLSConfig sets the TLS config used by StartTLS, e.g. to restrict cipher
+// suites. If its MinVersion is unset, Go's default minimum of TLS 1.2 applies.
+// It must be called before StartTLS.
+func (s *HttpServer) SetTLSConfig(cfg *tls.Config) {
+	s.srv.TLSConfig = cfg
+}
+
+// wait returns err, unless the server was shut down, in which case it waits
+// for the shutdown to complete and returns nil.
+func (s *HttpServer) wait(err error) error {
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-s.shutdownDone
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests to
+// finish, or for ctx to be done, whichever comes first.
+func (s *HttpServer) Shutdown(ctx context.Context) error {
+	defer s.shutdownOnce.Do(func() { close(s.shutdownDone) })
+	return s.srv.Shutdown(ctx)
 }
 
 func (s *HttpServer) RegisterRoutes(routes []*Route) {
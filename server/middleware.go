@@ -0,0 +1,48 @@
+package server
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/micahke/mirage/clients"
+)
+
+// routePath returns the route template that matched the request, such as
+// "/users/:id", so logs and metrics are not split by path parameters.
+func routePath(c *gin.Context) string {
+	if path := c.FullPath(); path != "" {
+		return path
+	}
+	return "unmatched"
+}
+
+// UseLoggingMiddleware logs every request with its method, route, status and
+// latency. Request IDs on the request context are included, see
+// clients.Logger.WithContext.
+func (s *HttpServer) UseLoggingMiddleware(logger clients.Logger) {
+	s.router.Use(func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		logger.WithContext(c.Request.Context()).Info("request",
+			"method", c.Request.Method,
+			"path", routePath(c),
+			"status", c.Writer.Status(),
+			"latency", time.Since(start),
+		)
+	})
+}
+
+// UseMetricsMiddleware counts every request in http_requests_total, labeled
+// by method, route and status, and records its latency in seconds in the
+// http_request_duration_seconds histogram.
+func (s *HttpServer) UseMetricsMiddleware(stats clients.StatsClient) {
+	requests := stats.CounterVec("http_requests_total", "method", "path", "status")
+	latency := stats.Histogram("http_request_duration_seconds", nil)
+	s.router.Use(func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		requests.With(c.Request.Method, routePath(c), strconv.Itoa(c.Writer.Status())).Inc()
+		latency.Observe(time.Since(start).Seconds())
+	})
+}
@@ -1,6 +1,10 @@
 package server
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
 
 type Route struct {
 	Method  string
@@ -11,6 +15,7 @@ type Route struct {
 
 type Server interface {
 	Start() error
+	Shutdown(ctx context.Context) error
 	Port() int
 	RegisterRoutes(routes []*Route)
 }
@@ -3,16 +3,70 @@ package utils
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 type GetRequest struct {
 	Url     string
 	Headers map[string]string
+
+	// Client sends the request. Defaults to http.DefaultClient.
+	Client *http.Client
+	// Timeout bounds each attempt, including reading the response. Zero
+	// means no timeout beyond the context's and the client's.
+	Timeout time.Duration
+	// Retries is how many times a request that fails with a connection error
+	// or a 5xx status is retried. GETs are idempotent, so retrying is safe.
+	Retries int
+	// RetryBackoff is the delay before the first retry. It doubles after
+	// each attempt.
+	RetryBackoff time.Duration
+}
+
+// HTTPError is returned when the server responds with a status of 400 or above.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	// Body holds the start of the response body, which often explains the error.
+	Body []byte
 }
 
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("http request failed: %s", e.Status)
+}
+
+// maxErrorBody is how much of an error response's body HTTPError keeps.
+const maxErrorBody = 64 << 10
+
 func HTTPGet[T any](ctx context.Context, req *GetRequest) (*T, error) {
-	request, err := http.NewRequest("GET", req.Url, nil)
+	backoff := req.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		data, err := httpGetOnce[T](ctx, req)
+		if err == nil || attempt >= req.Retries || !isRetryable(ctx, err) {
+			return data, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func httpGetOnce[T any](ctx context.Context, req *GetRequest) (*T, error) {
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "GET", req.Url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -21,12 +75,25 @@ func HTTPGet[T any](ctx context.Context, req *GetRequest) (*T, error) {
 			request.Header.Add(k, v)
 		}
 	}
-	response, err := http.DefaultClient.Do(request)
+	client := req.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+		return nil, &HTTPError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Body:       body,
+		}
+	}
+
 	var data T
 	err = json.NewDecoder(response.Body).Decode(&data)
 	if err != nil {
@@ -35,3 +102,18 @@ func HTTPGet[T any](ctx context.Context, req *GetRequest) (*T, error) {
 
 	return &data, nil
 }
+
+// isRetryable reports whether a failed attempt may succeed if repeated: the
+// server returned a 5xx status or the request failed to complete, other than
+// because ctx is done.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}